@@ -17,14 +17,14 @@ func RunUsersManagement(cl *client.DamlBindingClient) {
 		log.Info().Interface("user", u).Msg("received user details")
 	}
 
-	user, err := cl.UserMng.GetUser(context.Background(), "participant_admin")
+	user, err := cl.UserMng.GetUser(context.Background(), "participant_admin", "")
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to get user")
 	}
 
 	log.Info().Interface("user", user).Msg("single user details")
 
-	userRights, err := cl.UserMng.ListUserRights(context.Background(), user.ID)
+	userRights, err := cl.UserMng.ListUserRights(context.Background(), user.ID, "")
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to list user rights")
 	}
@@ -43,7 +43,7 @@ func RunUsersManagement(cl *client.DamlBindingClient) {
 		log.Info().Interface("right", r).Msg("user rights after grant")
 	}
 
-	updatedRights, err = cl.UserMng.RevokeUserRights(context.Background(), user.ID, newRights)
+	updatedRights, err = cl.UserMng.RevokeUserRights(context.Background(), user.ID, "", newRights)
 	if err != nil {
 		log.Warn().Err(err).Msg("failed to revoke user rights")
 	}
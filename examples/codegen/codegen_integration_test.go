@@ -63,7 +63,7 @@ func TestCodegenIntegration(t *testing.T) {
 		}
 	}
 
-	rights, err := cl.UserMng.ListUserRights(ctx, user)
+	rights, err := cl.UserMng.ListUserRights(ctx, user, "")
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to list user rights")
 	}
@@ -265,7 +265,7 @@ func TestCodegenIntegrationAllFieldsContract(t *testing.T) {
 		}
 	}()
 
-	rights, err := cl.UserMng.ListUserRights(ctx, user)
+	rights, err := cl.UserMng.ListUserRights(ctx, user, "")
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to list user rights")
 	}
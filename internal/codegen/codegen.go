@@ -12,6 +12,7 @@ import (
 
 	"github.com/noders-team/go-daml/internal/codegen/astgen"
 	"github.com/noders-team/go-daml/internal/codegen/model"
+	"github.com/noders-team/go-daml/pkg/dar"
 	"github.com/rs/zerolog/log"
 )
 
@@ -112,46 +113,7 @@ func GetManifest(srcPath string) (*model.Manifest, error) {
 	}
 	defer file.Close()
 
-	b, err := io.ReadAll(file)
-	if err != nil {
-		return nil, err
-	}
-
-	content := strings.ReplaceAll(string(b), "\n ", "")
-
-	manifest := &model.Manifest{}
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "Manifest-Version:") {
-			manifest.Version = strings.TrimSpace(strings.TrimPrefix(line, "Manifest-Version:"))
-		} else if strings.HasPrefix(line, "Created-By:") {
-			manifest.CreatedBy = strings.TrimSpace(strings.TrimPrefix(line, "Created-By:"))
-		} else if strings.HasPrefix(line, "Name:") {
-			manifest.Name = strings.TrimSpace(strings.TrimPrefix(line, "Name:"))
-		} else if strings.HasPrefix(line, "Sdk-Version:") {
-			manifest.SdkVersion = strings.TrimSpace(strings.TrimPrefix(line, "Sdk-Version:"))
-		} else if strings.HasPrefix(line, "Main-Dalf:") {
-			manifest.MainDalf = strings.TrimSpace(strings.TrimPrefix(line, "Main-Dalf:"))
-		} else if strings.HasPrefix(line, "Dalfs:") {
-			dalfsStr := strings.TrimSpace(strings.TrimPrefix(line, "Dalfs:"))
-			if dalfsStr != "" {
-				manifest.Dalfs = strings.Split(dalfsStr, ", ")
-				for i, dalf := range manifest.Dalfs {
-					manifest.Dalfs[i] = strings.TrimSpace(dalf)
-				}
-			}
-		} else if strings.HasPrefix(line, "Format:") {
-			manifest.Format = strings.TrimSpace(strings.TrimPrefix(line, "Format:"))
-		} else if strings.HasPrefix(line, "Encryption:") {
-			manifest.Encryption = strings.TrimSpace(strings.TrimPrefix(line, "Encryption:"))
-		}
-	}
-
-	if manifest.MainDalf == "" {
-		return nil, fmt.Errorf("main-dalf not found in manifest")
-	}
-
-	return manifest, nil
+	return dar.ParseManifest(file)
 }
 
 func CodegenDalfs(dalfToProcess []string, unzippedPath string, pkgFile string, dalfManifest *model.Manifest) (map[string]string, error) {
@@ -1,12 +1,6 @@
 package model
 
-type Manifest struct {
-	Version    string
-	CreatedBy  string
-	Name       string
-	SdkVersion string
-	MainDalf   string
-	Dalfs      []string
-	Format     string
-	Encryption string
-}
+import "github.com/noders-team/go-daml/pkg/dar"
+
+// Manifest is the DAR manifest, parsed by dar.ParseManifest.
+type Manifest = dar.Manifest
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Claims are the registered and scope claims of a JWT access token.
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	Scopes    []string
+	ExpiresAt time.Time
+	IssuedAt  time.Time
+	NotBefore time.Time
+	// Raw holds all claims, including the ones not mapped above.
+	Raw map[string]any
+}
+
+// ParseClaims decodes the claims of a JWT without verifying its signature;
+// verifying tokens is up to the participant.
+func ParseClaims(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JWT payload: %w", err)
+	}
+
+	var raw map[string]any
+	decoder := json.NewDecoder(strings.NewReader(string(payload)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JWT claims: %w", err)
+	}
+
+	claims := &Claims{
+		Subject:   stringClaim(raw["sub"]),
+		Issuer:    stringClaim(raw["iss"]),
+		Audience:  stringsClaim(raw["aud"]),
+		ExpiresAt: timeClaim(raw["exp"]),
+		IssuedAt:  timeClaim(raw["iat"]),
+		NotBefore: timeClaim(raw["nbf"]),
+		Raw:       raw,
+	}
+	// scope is space separated per RFC 8693; Okta and Azure use scp lists
+	if scope := stringClaim(raw["scope"]); scope != "" {
+		claims.Scopes = strings.Fields(scope)
+	} else {
+		claims.Scopes = stringsClaim(raw["scp"])
+	}
+
+	return claims, nil
+}
+
+// Expired reports whether the token has an expiry that lies before now.
+func (c *Claims) Expired(now time.Time) bool {
+	return !c.ExpiresAt.IsZero() && !now.Before(c.ExpiresAt)
+}
+
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func stringClaim(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func stringsClaim(v any) []string {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return strings.Fields(v)
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+func timeClaim(v any) time.Time {
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*float64(time.Second)))
+}
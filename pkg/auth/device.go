@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+type DeviceCodeConfig struct {
+	// Issuer is used to discover the endpoints that are not set explicitly.
+	Issuer                 string
+	DeviceAuthorizationURL string
+	TokenURL               string
+	ClientID               string
+	Scopes                 []string
+	Audience               string
+	// Prompt shows the user where to log in; defaults to printing the
+	// verification URI and user code to stderr.
+	Prompt     func(*DeviceAuthorization)
+	HTTPClient *http.Client
+	// CacheDir defaults to go-daml/tokens in the user config directory.
+	CacheDir     string
+	DisableCache bool
+}
+
+type DeviceAuthorization struct {
+	UserCode                string
+	VerificationURI         string
+	VerificationURIComplete string
+	ExpiresIn               time.Duration
+}
+
+type deviceCodeFlow struct {
+	prompt func(*DeviceAuthorization)
+}
+
+// NewDeviceCodeTokenProvider logs the user in with the OAuth2 device
+// authorization grant (RFC 8628): the user opens a URL on any device and enters
+// a code while the provider polls for the token.
+func NewDeviceCodeTokenProvider(cfg DeviceCodeConfig) (*interactiveTokenProvider, error) {
+	prompt := cfg.Prompt
+	if prompt == nil {
+		prompt = printDeviceAuthorization
+	}
+
+	return newInteractiveTokenProvider(cfg.Issuer, cfg.ClientID, cfg.Scopes, cfg.Audience, OIDCProviderMetadata{
+		TokenEndpoint:               cfg.TokenURL,
+		DeviceAuthorizationEndpoint: cfg.DeviceAuthorizationURL,
+	}, cfg.HTTPClient, cfg.CacheDir, cfg.DisableCache, &deviceCodeFlow{prompt: prompt})
+}
+
+func (f *deviceCodeFlow) login(ctx context.Context, p *interactiveTokenProvider) (*tokenResponse, error) {
+	if p.endpoints.DeviceAuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("identity provider has no device authorization endpoint")
+	}
+
+	var authResp struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		// Some providers still use the draft name.
+		VerificationURL string `json:"verification_url"`
+		ExpiresIn       int    `json:"expires_in"`
+		Interval        int    `json:"interval"`
+	}
+	values := p.scopeAndAudience(url.Values{"client_id": {p.clientID}})
+	if err := postForm(ctx, p.httpClient, p.endpoints.DeviceAuthorizationEndpoint, values, nil, &authResp); err != nil {
+		return nil, fmt.Errorf("device authorization request failed: %w", err)
+	}
+	if authResp.DeviceCode == "" {
+		return nil, fmt.Errorf("device authorization response missing device_code")
+	}
+	if authResp.VerificationURI == "" {
+		authResp.VerificationURI = authResp.VerificationURL
+	}
+
+	expiresIn := time.Duration(authResp.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 10 * time.Minute
+	}
+	f.prompt(&DeviceAuthorization{
+		UserCode:                authResp.UserCode,
+		VerificationURI:         authResp.VerificationURI,
+		VerificationURIComplete: authResp.VerificationURIComplete,
+		ExpiresIn:               expiresIn,
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, expiresIn)
+	defer cancel()
+
+	interval := time.Duration(authResp.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("device login was not completed: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+
+		var tokenResp tokenResponse
+		err := p.requestTokenLocked(ctx, url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code": {authResp.DeviceCode},
+		}, &tokenResp)
+		if err == nil {
+			return &tokenResp, nil
+		}
+
+		var oauthErr *OAuthError
+		if !errors.As(err, &oauthErr) {
+			return nil, err
+		}
+		switch oauthErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, err
+		}
+	}
+}
+
+func printDeviceAuthorization(auth *DeviceAuthorization) {
+	if auth.VerificationURIComplete != "" {
+		fmt.Fprintf(os.Stderr, "To log in, open %s and confirm the code %s\n", auth.VerificationURIComplete, auth.UserCode)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "To log in, open %s and enter the code %s\n", auth.VerificationURI, auth.UserCode)
+}
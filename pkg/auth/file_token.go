@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type FileTokenConfig struct {
+	// Path of the token file, e.g. a projected service account token or the
+	// sink of a Vault agent.
+	Path string
+	// PollInterval defaults to 10 seconds.
+	PollInterval time.Duration
+	// OnReload is called after a changed token was loaded. claims is nil for
+	// tokens that are not JWTs.
+	OnReload func(claims *Claims)
+	// OnError is called when the file cannot be read; the last token is kept.
+	OnError func(err error)
+}
+
+// fileTokenProvider serves the token stored in a file and reloads it when the
+// file changes, so rotated tokens are picked up without a restart.
+type fileTokenProvider struct {
+	cfg FileTokenConfig
+
+	mu      sync.RWMutex
+	token   string
+	claims  *Claims
+	loadErr error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewFileTokenProvider reads the token file and polls it for changes until ctx
+// is cancelled or Close is called.
+func NewFileTokenProvider(ctx context.Context, cfg FileTokenConfig) (*fileTokenProvider, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("token file path is required")
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 10 * time.Second
+	}
+
+	p := &fileTokenProvider{
+		cfg:  cfg,
+		done: make(chan struct{}),
+	}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	ctx, p.cancel = context.WithCancel(ctx)
+	go p.watch(ctx)
+
+	return p, nil
+}
+
+// Token returns the token last read from the file. It fails once that token
+// has expired, after checking the file for a newer one.
+func (p *fileTokenProvider) Token() (string, error) {
+	token, claims, _ := p.current()
+	if claims == nil || !claims.Expired(time.Now()) {
+		return token, nil
+	}
+
+	// reading a local file is cheap, so do not wait for the next poll
+	_ = p.reload()
+	token, claims, loadErr := p.current()
+	if claims == nil || !claims.Expired(time.Now()) {
+		return token, nil
+	}
+
+	err := fmt.Errorf("token in %s expired at %s", p.cfg.Path, claims.ExpiresAt.Format(time.RFC3339))
+	if loadErr != nil {
+		return "", fmt.Errorf("%w: %w", err, loadErr)
+	}
+	return "", err
+}
+
+// Claims returns the claims of the current token, or nil if it is not a JWT.
+func (p *fileTokenProvider) Claims() *Claims {
+	_, claims, _ := p.current()
+	return claims
+}
+
+// Close stops watching the file.
+func (p *fileTokenProvider) Close() {
+	p.cancel()
+	<-p.done
+}
+
+func (p *fileTokenProvider) current() (string, *Claims, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.token, p.claims, p.loadErr
+}
+
+func (p *fileTokenProvider) watch(ctx context.Context) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := p.reload(); err != nil {
+			log.Warn().Err(err).Str("path", p.cfg.Path).Msg("failed to reload token file")
+		}
+	}
+}
+
+// reload reads the file and swaps in its token if it changed. The contents are
+// compared rather than the modification time, since Kubernetes updates
+// projected volumes by swapping a symlink.
+func (p *fileTokenProvider) reload() error {
+	token, err := readTokenFile(p.cfg.Path)
+
+	p.mu.Lock()
+	if err != nil {
+		p.loadErr = err
+		p.mu.Unlock()
+
+		if p.cfg.OnError != nil {
+			p.cfg.OnError(err)
+		}
+		return err
+	}
+
+	p.loadErr = nil
+	if token == p.token {
+		p.mu.Unlock()
+		return nil
+	}
+	claims, _ := ParseClaims(token)
+	p.token = token
+	p.claims = claims
+	p.mu.Unlock()
+
+	if claims != nil && claims.Expired(time.Now()) {
+		log.Warn().Str("path", p.cfg.Path).Time("expires_at", claims.ExpiresAt).Msg("token file contains an expired token")
+	}
+	if p.cfg.OnReload != nil {
+		p.cfg.OnReload(claims)
+	}
+	return nil
+}
+
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", errors.New("token file is empty")
+	}
+	return token, nil
+}
+
+func (p *fileTokenProvider) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := p.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"authorization": fmt.Sprintf("Bearer %s", token),
+	}, nil
+}
+
+func (p *fileTokenProvider) RequireTransportSecurity() bool {
+	return false
+}
+
+func (p *fileTokenProvider) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := p.Token()
+		if err != nil {
+			return err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (p *fileTokenProvider) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		token, err := p.Token()
+		if err != nil {
+			return nil, err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeTokenFile replaces the file atomically, like a Vault agent does.
+func writeTokenFile(t *testing.T, path, token string) {
+	t.Helper()
+
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(token+"\n"), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func TestFileTokenProviderReloads(t *testing.T) {
+	signer, err := NewJWTSigner([]byte("secret"), "")
+	require.NoError(t, err)
+	first, err := signer.Mint(LedgerClaims{UserID: "alice"})
+	require.NoError(t, err)
+	second, err := signer.Mint(LedgerClaims{UserID: "bob"})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "token")
+	writeTokenFile(t, path, first)
+
+	var reloads atomic.Int32
+	p, err := NewFileTokenProvider(context.Background(), FileTokenConfig{
+		Path:         path,
+		PollInterval: 20 * time.Millisecond,
+		OnReload:     func(*Claims) { reloads.Add(1) },
+	})
+	require.NoError(t, err)
+	defer p.Close()
+
+	token, err := p.Token()
+	require.NoError(t, err)
+	require.Equal(t, first, token)
+
+	writeTokenFile(t, path, second)
+	require.Eventually(t, func() bool {
+		token, err := p.Token()
+		return err == nil && token == second
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "bob", p.Claims().Subject)
+	require.Equal(t, int32(2), reloads.Load())
+
+	// a missing file keeps the last token
+	require.NoError(t, os.Remove(path))
+	time.Sleep(60 * time.Millisecond)
+	token, err = p.Token()
+	require.NoError(t, err)
+	require.Equal(t, second, token)
+}
+
+func TestFileTokenProviderExpiredToken(t *testing.T) {
+	expired, err := signJWT(map[string]any{"sub": "alice", "exp": time.Now().Add(-time.Minute).Unix()}, []byte("secret"), "")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "token")
+	writeTokenFile(t, path, expired)
+
+	p, err := NewFileTokenProvider(context.Background(), FileTokenConfig{Path: path})
+	require.NoError(t, err)
+	defer p.Close()
+
+	_, err = p.Token()
+	require.ErrorContains(t, err, "expired")
+
+	// a rotated token is picked up without waiting for the next poll
+	valid, err := signJWT(map[string]any{"sub": "alice", "exp": time.Now().Add(time.Hour).Unix()}, []byte("secret"), "")
+	require.NoError(t, err)
+	writeTokenFile(t, path, valid)
+	token, err := p.Token()
+	require.NoError(t, err)
+	require.Equal(t, valid, token)
+}
+
+func TestFileTokenProviderMissingFile(t *testing.T) {
+	_, err := NewFileTokenProvider(context.Background(), FileTokenConfig{Path: filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err)
+}
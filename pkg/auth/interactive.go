@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// interactiveFlow logs a user in; implemented by the device code and PKCE
+// flows.
+type interactiveFlow interface {
+	login(ctx context.Context, p *interactiveTokenProvider) (*tokenResponse, error)
+}
+
+// interactiveTokenProvider obtains tokens for a user through an interactive
+// login, keeps them in a token cache and refreshes them with the refresh
+// token, so the user only logs in again once the refresh token has expired.
+type interactiveTokenProvider struct {
+	mu         sync.Mutex
+	httpClient *http.Client
+	issuer     string
+	clientID   string
+	scopes     []string
+	audience   string
+	// endpoints holds explicitly configured endpoints until discovery filled
+	// in the rest.
+	endpoints  OIDCProviderMetadata
+	discovered bool
+	cache      *FileTokenCache
+	token      *CachedToken
+	flow       interactiveFlow
+}
+
+func newInteractiveTokenProvider(issuer, clientID string, scopes []string, audience string, endpoints OIDCProviderMetadata,
+	httpClient *http.Client, cacheDir string, disableCache bool, flow interactiveFlow,
+) (*interactiveTokenProvider, error) {
+	if clientID == "" {
+		return nil, fmt.Errorf("client id is required")
+	}
+	if issuer == "" && endpoints.TokenEndpoint == "" {
+		return nil, fmt.Errorf("either issuer or token url is required")
+	}
+	if httpClient == nil {
+		httpClient = defaultHTTPClient()
+	}
+
+	p := &interactiveTokenProvider{
+		httpClient: httpClient,
+		issuer:     issuer,
+		clientID:   clientID,
+		scopes:     scopes,
+		audience:   audience,
+		endpoints:  endpoints,
+		discovered: issuer == "",
+		flow:       flow,
+	}
+	if !disableCache {
+		cacheKey := issuer
+		if cacheKey == "" {
+			cacheKey = endpoints.TokenEndpoint
+		}
+		cache, err := NewFileTokenCache(cacheDir, cacheKey, clientID, scopes, audience)
+		if err != nil {
+			return nil, err
+		}
+		p.cache = cache
+	}
+
+	return p, nil
+}
+
+func (p *interactiveTokenProvider) Token() (string, error) {
+	return p.TokenContext(context.Background())
+}
+
+// TokenContext returns a valid access token. It refreshes an expired token and
+// starts an interactive login if there is no usable refresh token.
+func (p *interactiveTokenProvider) TokenContext(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.loadCacheLocked()
+	if p.token != nil && p.token.AccessToken != "" && time.Now().Before(p.token.ExpiresAt) {
+		return p.token.AccessToken, nil
+	}
+
+	if err := p.discoverLocked(ctx); err != nil {
+		return "", err
+	}
+
+	if p.token != nil && p.token.RefreshToken != "" {
+		token, err := p.refreshLocked(ctx)
+		if err == nil {
+			return token, nil
+		}
+		log.Warn().Err(err).Msg("failed to refresh token, starting a new login")
+	}
+
+	return p.loginLocked(ctx)
+}
+
+// FetchToken renews the access token with the refresh token. Unlike
+// TokenContext it never starts an interactive login, so it can run in the
+// background.
+func (p *interactiveTokenProvider) FetchToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.loadCacheLocked()
+	if p.token == nil || p.token.RefreshToken == "" {
+		return "", fmt.Errorf("no refresh token, the user has to log in")
+	}
+	if err := p.discoverLocked(ctx); err != nil {
+		return "", err
+	}
+
+	return p.refreshLocked(ctx)
+}
+
+// Login starts an interactive login even if a valid token is cached, e.g. to
+// switch users.
+func (p *interactiveTokenProvider) Login(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.discoverLocked(ctx); err != nil {
+		return err
+	}
+	_, err := p.loginLocked(ctx)
+	return err
+}
+
+// Logout forgets the tokens, including the cached ones.
+func (p *interactiveTokenProvider) Logout() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.token = nil
+	if p.cache != nil {
+		return p.cache.Clear()
+	}
+	return nil
+}
+
+func (p *interactiveTokenProvider) loadCacheLocked() {
+	if p.token != nil || p.cache == nil {
+		return
+	}
+
+	cached, err := p.cache.Load()
+	if err != nil {
+		log.Warn().Err(err).Msg("ignoring unreadable token cache")
+	}
+	p.token = cached
+}
+
+func (p *interactiveTokenProvider) refreshLocked(ctx context.Context) (string, error) {
+	var tokenResp tokenResponse
+	err := p.requestTokenLocked(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {p.token.RefreshToken},
+	}, &tokenResp)
+	if err != nil {
+		return "", err
+	}
+	return p.storeLocked(&tokenResp)
+}
+
+func (p *interactiveTokenProvider) loginLocked(ctx context.Context) (string, error) {
+	tokenResp, err := p.flow.login(ctx, p)
+	if err != nil {
+		return "", fmt.Errorf("interactive login failed: %w", err)
+	}
+	return p.storeLocked(tokenResp)
+}
+
+func (p *interactiveTokenProvider) storeLocked(tokenResp *tokenResponse) (string, error) {
+	if tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("token response missing access_token")
+	}
+
+	token := &CachedToken{
+		AccessToken: tokenResp.AccessToken,
+		ExpiresAt:   tokenResp.expiresAt(),
+	}
+	if tokenResp.RefreshToken != "" {
+		token.RefreshToken = tokenResp.RefreshToken
+	} else if p.token != nil {
+		token.RefreshToken = p.token.RefreshToken
+	}
+	p.token = token
+
+	if p.cache != nil {
+		if err := p.cache.Save(token); err != nil {
+			log.Warn().Err(err).Str("path", p.cache.Path()).Msg("failed to cache token")
+		}
+	}
+	return token.AccessToken, nil
+}
+
+func (p *interactiveTokenProvider) discoverLocked(ctx context.Context) error {
+	if p.discovered {
+		return nil
+	}
+
+	md, err := DiscoverOIDC(ctx, p.httpClient, p.issuer)
+	if err != nil {
+		return err
+	}
+	if p.endpoints.TokenEndpoint == "" {
+		p.endpoints.TokenEndpoint = md.TokenEndpoint
+	}
+	if p.endpoints.AuthorizationEndpoint == "" {
+		p.endpoints.AuthorizationEndpoint = md.AuthorizationEndpoint
+	}
+	if p.endpoints.DeviceAuthorizationEndpoint == "" {
+		p.endpoints.DeviceAuthorizationEndpoint = md.DeviceAuthorizationEndpoint
+	}
+	p.discovered = true
+	return nil
+}
+
+// requestTokenLocked calls the token endpoint as a public client.
+func (p *interactiveTokenProvider) requestTokenLocked(ctx context.Context, values url.Values, out any) error {
+	values.Set("client_id", p.clientID)
+	return postForm(ctx, p.httpClient, p.endpoints.TokenEndpoint, values, nil, out)
+}
+
+func (p *interactiveTokenProvider) scopeAndAudience(values url.Values) url.Values {
+	if len(p.scopes) > 0 {
+		values.Set("scope", strings.Join(p.scopes, " "))
+	}
+	if p.audience != "" {
+		values.Set("audience", p.audience)
+	}
+	return values
+}
+
+func (p *interactiveTokenProvider) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := p.TokenContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"authorization": fmt.Sprintf("Bearer %s", token),
+	}, nil
+}
+
+func (p *interactiveTokenProvider) RequireTransportSecurity() bool {
+	return false
+}
+
+func (p *interactiveTokenProvider) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := p.TokenContext(ctx)
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (p *interactiveTokenProvider) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		token, err := p.TokenContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
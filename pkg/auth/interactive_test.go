@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceCodeTokenProviderCachesAndRefreshes(t *testing.T) {
+	polls := 0
+	srv := newOIDCServer(t, func(r *http.Request) (int, any) {
+		require.Equal(t, "cli", r.PostForm.Get("client_id"))
+		switch r.PostForm.Get("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			require.Equal(t, "dev-123", r.PostForm.Get("device_code"))
+			polls++
+			if polls == 1 {
+				return http.StatusBadRequest, map[string]any{"error": "authorization_pending"}
+			}
+			return http.StatusOK, map[string]any{"access_token": "user-tok", "refresh_token": "user-refresh", "expires_in": 1}
+		case "refresh_token":
+			require.Equal(t, "user-refresh", r.PostForm.Get("refresh_token"))
+			return http.StatusOK, map[string]any{"access_token": "user-tok-2", "expires_in": 300}
+		}
+		t.Fatalf("unexpected grant %q", r.PostForm.Get("grant_type"))
+		return 0, nil
+	})
+	device := http.NewServeMux()
+	device.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"device_code":"dev-123","user_code":"ABCD-EFGH","verification_uri":"https://login.example.com/activate","expires_in":60,"interval":1}`))
+	})
+	deviceSrv := httptest.NewServer(device)
+	t.Cleanup(deviceSrv.Close)
+
+	cacheDir := t.TempDir()
+	var prompted *DeviceAuthorization
+	cfg := DeviceCodeConfig{
+		Issuer:                 srv.URL,
+		DeviceAuthorizationURL: deviceSrv.URL + "/device",
+		ClientID:               "cli",
+		Scopes:                 []string{"openid", LedgerAPIScope},
+		Prompt:                 func(a *DeviceAuthorization) { prompted = a },
+		HTTPClient:             srv.Client(),
+		CacheDir:               cacheDir,
+	}
+	p, err := NewDeviceCodeTokenProvider(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	token, err := p.TokenContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "user-tok", token)
+	require.Equal(t, "ABCD-EFGH", prompted.UserCode)
+	require.Equal(t, 2, polls)
+
+	// a new process picks up the cached refresh token instead of prompting again
+	cfg.Prompt = func(*DeviceAuthorization) { t.Fatal("unexpected login prompt") }
+	p2, err := NewDeviceCodeTokenProvider(cfg)
+	require.NoError(t, err)
+	token, err = p2.TokenContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "user-tok-2", token)
+
+	require.NoError(t, p2.Logout())
+	cached, err := p2.cache.Load()
+	require.NoError(t, err)
+	require.Nil(t, cached)
+}
+
+func TestPKCETokenProvider(t *testing.T) {
+	var challenge string
+	srv := newOIDCServer(t, func(r *http.Request) (int, any) {
+		require.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+		require.Equal(t, "auth-code", r.PostForm.Get("code"))
+		verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		require.Equal(t, challenge, base64.RawURLEncoding.EncodeToString(verifier[:]))
+		return http.StatusOK, map[string]any{"access_token": "pkce-tok", "expires_in": 300}
+	})
+
+	p, err := NewPKCETokenProvider(PKCEConfig{
+		TokenURL:         srv.URL + "/oauth/token",
+		AuthorizationURL: "https://login.example.com/authorize",
+		ClientID:         "cli",
+		// stands in for the user logging in and the browser following the redirect
+		OpenBrowser: func(authURL string) error {
+			u, err := url.Parse(authURL)
+			require.NoError(t, err)
+			q := u.Query()
+			require.Equal(t, "S256", q.Get("code_challenge_method"))
+			challenge = q.Get("code_challenge")
+
+			go func() {
+				resp, err := http.Get(q.Get("redirect_uri") + "?code=auth-code&state=" + url.QueryEscape(q.Get("state")))
+				if err == nil {
+					resp.Body.Close()
+				}
+			}()
+			return nil
+		},
+		HTTPClient:   srv.Client(),
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	token, err := p.TokenContext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "pkce-tok", token)
+}
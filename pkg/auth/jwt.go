@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+)
+
+// jwtAlgorithm picks the signing algorithm for a key: []byte for HS256,
+// *rsa.PrivateKey for RS256 and a P-256 *ecdsa.PrivateKey for ES256.
+func jwtAlgorithm(key any) (string, error) {
+	switch k := key.(type) {
+	case []byte:
+		return AlgorithmHS256, nil
+	case *rsa.PrivateKey:
+		return AlgorithmRS256, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return "", fmt.Errorf("unsupported ECDSA curve %s, only P-256 is supported", k.Curve.Params().Name)
+		}
+		return AlgorithmES256, nil
+	default:
+		return "", fmt.Errorf("unsupported JWT signing key type %T", key)
+	}
+}
+
+func signJWT(claims any, key any, keyID string) (string, error) {
+	alg, err := jwtAlgorithm(key)
+	if err != nil {
+		return "", err
+	}
+
+	header := map[string]string{"alg": alg, "typ": "JWT"}
+	if keyID != "" {
+		header["kid"] = keyID
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT header: %w", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		// JWS uses the fixed-size r || s encoding rather than ASN.1.
+		var r, s []byte
+		rInt, sInt, signErr := ecdsa.Sign(rand.Reader, k, digest[:])
+		if signErr == nil {
+			r, s = make([]byte, 32), make([]byte, 32)
+			rInt.FillBytes(r)
+			sInt.FillBytes(s)
+		}
+		signature, err = append(r, s...), signErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
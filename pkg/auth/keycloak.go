@@ -64,29 +64,48 @@ func NewKeycloakTokenProvider(cfg KeycloakConfig) (*keycloakTokenProvider, error
 }
 
 func (p *keycloakTokenProvider) Token() (string, error) {
+	return p.TokenContext(context.Background())
+}
+
+// TokenContext returns the cached access token, requesting a new one with ctx
+// if it is about to expire.
+func (p *keycloakTokenProvider) TokenContext(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.accessToken != "" && time.Now().Before(p.expiresAt) {
 		return p.accessToken, nil
 	}
 
+	return p.fetchLocked(ctx)
+}
+
+// FetchToken requests a new access token even if the cached one is still
+// valid.
+func (p *keycloakTokenProvider) FetchToken(ctx context.Context) (string, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	return p.fetchLocked(ctx)
+}
+
+func (p *keycloakTokenProvider) fetchLocked(ctx context.Context) (string, error) {
 	if p.refreshToken != "" {
-		if err := p.refreshLocked(); err == nil {
+		if err := p.refreshLocked(ctx); err == nil {
 			return p.accessToken, nil
 		} else {
 			log.Warn().Err(err).Msg("failed to refresh keycloak token, fetching a new access token")
 		}
 	}
 
-	if err := p.fetchClientCredentialsLocked(); err != nil {
+	if err := p.fetchClientCredentialsLocked(ctx); err != nil {
 		return "", err
 	}
 
 	return p.accessToken, nil
 }
 
-func (p *keycloakTokenProvider) refreshLocked() error {
+func (p *keycloakTokenProvider) refreshLocked(ctx context.Context) error {
 	values := url.Values{}
 	values.Set("grant_type", "refresh_token")
 	values.Set("refresh_token", p.refreshToken)
@@ -95,10 +114,10 @@ func (p *keycloakTokenProvider) refreshLocked() error {
 	if p.audience != "" {
 		values.Set("audience", p.audience)
 	}
-	return p.requestTokenLocked(values)
+	return p.requestTokenLocked(ctx, values)
 }
 
-func (p *keycloakTokenProvider) fetchClientCredentialsLocked() error {
+func (p *keycloakTokenProvider) fetchClientCredentialsLocked(ctx context.Context) error {
 	values := url.Values{}
 	values.Set("grant_type", "client_credentials")
 	values.Set("client_id", p.clientID)
@@ -106,11 +125,11 @@ func (p *keycloakTokenProvider) fetchClientCredentialsLocked() error {
 	if p.audience != "" {
 		values.Set("audience", p.audience)
 	}
-	return p.requestTokenLocked(values)
+	return p.requestTokenLocked(ctx, values)
 }
 
-func (p *keycloakTokenProvider) requestTokenLocked(values url.Values) error {
-	req, err := http.NewRequest("POST", p.tokenURL, strings.NewReader(values.Encode()))
+func (p *keycloakTokenProvider) requestTokenLocked(ctx context.Context, values url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", p.tokenURL, strings.NewReader(values.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to build keycloak token request: %w", err)
 	}
@@ -156,7 +175,7 @@ func (p *keycloakTokenProvider) requestTokenLocked(values url.Values) error {
 }
 
 func (p *keycloakTokenProvider) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
-	token, err := p.Token()
+	token, err := p.TokenContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +191,7 @@ func (p *keycloakTokenProvider) RequireTransportSecurity() bool {
 
 func (p *keycloakTokenProvider) UnaryInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		token, err := p.Token()
+		token, err := p.TokenContext(ctx)
 		if err != nil {
 			return err
 		}
@@ -186,7 +205,7 @@ func (p *keycloakTokenProvider) UnaryInterceptor() grpc.UnaryClientInterceptor {
 
 func (p *keycloakTokenProvider) StreamInterceptor() grpc.StreamClientInterceptor {
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-		token, err := p.Token()
+		token, err := p.TokenContext(ctx)
 		if err != nil {
 			return nil, err
 		}
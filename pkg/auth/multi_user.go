@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type userIDContextKey struct{}
+
+type tokenContextKey struct{}
+
+// WithUserID returns a context whose calls act as userID when the connection
+// uses a multi-user token provider.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDContextKey{}, userID)
+}
+
+// WithToken returns a context whose calls send token, e.g. one forwarded from
+// an end user, when the connection uses a multi-user token provider.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, token)
+}
+
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDContextKey{}).(string)
+	return userID, ok && userID != ""
+}
+
+func TokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenContextKey{}).(string)
+	return token, ok && token != ""
+}
+
+type MultiUserConfig struct {
+	// Default serves calls whose context carries neither a user nor a token;
+	// nil rejects them.
+	Default TokenProvider
+	// NewProvider creates the provider of a user on first use, e.g. a
+	// self-issued provider or a device code login per user. When nil, only
+	// users added with Register are known.
+	NewProvider func(userID string) (TokenProvider, error)
+}
+
+type userProvider struct {
+	once     sync.Once
+	provider TokenProvider
+	err      error
+}
+
+// multiUserTokenProvider picks the token per call from the call's context, so
+// a single connection can act for many users. A token set with WithToken is
+// sent as is; a user set with WithUserID gets the token of its registered
+// provider.
+type multiUserTokenProvider struct {
+	cfg MultiUserConfig
+
+	mu        sync.Mutex
+	providers map[string]*userProvider
+}
+
+func NewMultiUserTokenProvider(cfg MultiUserConfig) *multiUserTokenProvider {
+	return &multiUserTokenProvider{
+		cfg:       cfg,
+		providers: make(map[string]*userProvider),
+	}
+}
+
+// Register sets the provider used for calls acting as userID.
+func (p *multiUserTokenProvider) Register(userID string, provider TokenProvider) {
+	entry := &userProvider{provider: provider}
+	entry.once.Do(func() {})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.providers[userID] = entry
+}
+
+// Unregister forgets the provider of userID. Closing it is up to the caller.
+func (p *multiUserTokenProvider) Unregister(userID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.providers, userID)
+}
+
+// Provider returns the provider of userID, creating it with NewProvider on
+// first use.
+func (p *multiUserTokenProvider) Provider(userID string) (TokenProvider, error) {
+	p.mu.Lock()
+	entry, ok := p.providers[userID]
+	if !ok {
+		if p.cfg.NewProvider == nil {
+			p.mu.Unlock()
+			return nil, fmt.Errorf("no token provider registered for user %q", userID)
+		}
+		entry = &userProvider{}
+		p.providers[userID] = entry
+	}
+	p.mu.Unlock()
+
+	// created outside the lock, so a slow provider does not hold up other users
+	entry.once.Do(func() {
+		entry.provider, entry.err = p.cfg.NewProvider(userID)
+		if entry.err == nil && entry.provider == nil {
+			entry.err = fmt.Errorf("no token provider created for user %q", userID)
+		}
+	})
+	if entry.err != nil {
+		p.mu.Lock()
+		if p.providers[userID] == entry {
+			delete(p.providers, userID)
+		}
+		p.mu.Unlock()
+		return nil, fmt.Errorf("failed to create token provider for user %q: %w", userID, entry.err)
+	}
+
+	return entry.provider, nil
+}
+
+// Token returns the token of the default provider.
+func (p *multiUserTokenProvider) Token() (string, error) {
+	return p.TokenContext(context.Background())
+}
+
+// TokenContext returns the token for a call made with ctx.
+func (p *multiUserTokenProvider) TokenContext(ctx context.Context) (string, error) {
+	if token, ok := TokenFromContext(ctx); ok {
+		return token, nil
+	}
+
+	provider := p.cfg.Default
+	if userID, ok := UserIDFromContext(ctx); ok {
+		var err error
+		if provider, err = p.Provider(userID); err != nil {
+			return "", err
+		}
+	} else if provider == nil {
+		return "", fmt.Errorf("no user or token in context and no default token provider")
+	}
+
+	if cp, ok := provider.(interface {
+		TokenContext(ctx context.Context) (string, error)
+	}); ok {
+		return cp.TokenContext(ctx)
+	}
+	return provider.Token()
+}
+
+func (p *multiUserTokenProvider) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := p.TokenContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, nil
+	}
+
+	return map[string]string{
+		"authorization": fmt.Sprintf("Bearer %s", token),
+	}, nil
+}
+
+func (p *multiUserTokenProvider) RequireTransportSecurity() bool {
+	return false
+}
+
+func (p *multiUserTokenProvider) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := p.TokenContext(ctx)
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (p *multiUserTokenProvider) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		token, err := p.TokenContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
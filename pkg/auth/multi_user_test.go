@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// callAs runs the unary interceptor and returns the authorization header the
+// call would send.
+func callAs(ctx context.Context, p TokenProvider) (string, error) {
+	var header string
+	err := p.UnaryInterceptor()(ctx, "/test", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+		return nil
+	})
+	return header, err
+}
+
+func TestMultiUserTokenProvider(t *testing.T) {
+	signer, err := NewJWTSigner([]byte("secret"), "")
+	require.NoError(t, err)
+
+	var created atomic.Int32
+	p := NewMultiUserTokenProvider(MultiUserConfig{
+		Default: NewBearerTokenProvider("service-token"),
+		NewProvider: func(userID string) (TokenProvider, error) {
+			if userID == "mallory" {
+				return nil, errors.New("unknown user")
+			}
+			created.Add(1)
+			return NewSelfIssuedTokenProvider(signer, LedgerClaims{UserID: userID, Scope: LedgerAPIScope}), nil
+		},
+	})
+	p.Register("bob", NewBearerTokenProvider("bob-token"))
+
+	ctx := context.Background()
+
+	header, err := callAs(ctx, p)
+	require.NoError(t, err)
+	require.Equal(t, "Bearer service-token", header)
+
+	header, err = callAs(WithUserID(ctx, "bob"), p)
+	require.NoError(t, err)
+	require.Equal(t, "Bearer bob-token", header)
+
+	header, err = callAs(WithToken(ctx, "forwarded"), p)
+	require.NoError(t, err)
+	require.Equal(t, "Bearer forwarded", header)
+
+	// concurrent calls for the same user share one provider
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			header, err := callAs(WithUserID(ctx, "alice"), p)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			claims, err := ParseClaims(strings.TrimPrefix(header, "Bearer "))
+			if err != nil || claims.Subject != "alice" {
+				t.Errorf("unexpected token for alice: %q", header)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), created.Load())
+
+	_, err = callAs(WithUserID(ctx, "mallory"), p)
+	require.ErrorContains(t, err, "unknown user")
+
+	p.Unregister("bob")
+	header, err = callAs(WithUserID(ctx, "bob"), p)
+	require.NoError(t, err)
+	require.Contains(t, header, "Bearer ey")
+}
+
+func TestMultiUserTokenProviderWithoutDefault(t *testing.T) {
+	p := NewMultiUserTokenProvider(MultiUserConfig{})
+
+	_, err := callAs(context.Background(), p)
+	require.Error(t, err)
+
+	_, err = callAs(WithUserID(context.Background(), "alice"), p)
+	require.ErrorContains(t, err, `no token provider registered for user "alice"`)
+}
@@ -0,0 +1,412 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// LedgerAPIScope is the scope Canton expects in audience-based user tokens.
+const LedgerAPIScope = "daml_ledger_api"
+
+type ClientAuthMethod string
+
+const (
+	ClientAuthSecretBasic   ClientAuthMethod = "client_secret_basic"
+	ClientAuthSecretPost    ClientAuthMethod = "client_secret_post"
+	ClientAuthPrivateKeyJWT ClientAuthMethod = "private_key_jwt"
+	// ClientAuthNone is used by public clients, which only send their client ID.
+	ClientAuthNone ClientAuthMethod = "none"
+)
+
+type OIDCConfig struct {
+	// Issuer is used to discover the token endpoint unless TokenURL is set.
+	Issuer   string
+	TokenURL string
+	ClientID string
+	// AuthMethod defaults to client_secret_basic when ClientSecret is set,
+	// to private_key_jwt when PrivateKey is set and to none otherwise.
+	AuthMethod   ClientAuthMethod
+	ClientSecret string
+	// PrivateKey signs private_key_jwt client assertions; *rsa.PrivateKey or
+	// a P-256 *ecdsa.PrivateKey. KeyID is put in the assertion header.
+	PrivateKey any
+	KeyID      string
+	Scopes     []string
+	Audience   string
+	// RefreshToken, if set, is used before falling back to client credentials.
+	RefreshToken string
+	HTTPClient   *http.Client
+}
+
+type OIDCProviderMetadata struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint       string   `json:"device_authorization_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// OAuthError is an error response of an OAuth2 endpoint.
+type OAuthError struct {
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth error %q (status %d): %s", e.Code, e.StatusCode, e.Description)
+	}
+	return fmt.Sprintf("oauth error %q (status %d)", e.Code, e.StatusCode)
+}
+
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:       10 * time.Second,
+		CheckRedirect: DenyPrivateRedirects,
+	}
+}
+
+// DiscoverOIDC fetches the provider metadata from the issuer's
+// .well-known/openid-configuration document.
+func DiscoverOIDC(ctx context.Context, httpClient *http.Client, issuer string) (*OIDCProviderMetadata, error) {
+	if httpClient == nil {
+		httpClient = defaultHTTPClient()
+	}
+
+	base := strings.TrimRight(issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OIDC discovery request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OIDC discovery response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("OIDC discovery request failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var md OIDCProviderMetadata
+	if err := json.Unmarshal(body, &md); err != nil {
+		return nil, fmt.Errorf("failed to parse OIDC discovery response: %w", err)
+	}
+	if strings.TrimRight(md.Issuer, "/") != base {
+		return nil, fmt.Errorf("OIDC discovery issuer mismatch: expected %q, got %q", issuer, md.Issuer)
+	}
+	if md.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC discovery response missing token_endpoint")
+	}
+
+	return &md, nil
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// expiresAt returns when the token should be renewed, 30 seconds before it
+// actually expires.
+func (r *tokenResponse) expiresAt() time.Time {
+	expiresIn := time.Duration(r.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 30 * time.Minute
+	}
+	return time.Now().Add(expiresIn - 30*time.Second)
+}
+
+// postForm posts an OAuth2 form request and decodes the JSON response into
+// out. Error responses are returned as *OAuthError when they follow RFC 6749.
+func postForm(ctx context.Context, httpClient *http.Client, endpoint string, values url.Values, header http.Header, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build token request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var oauthErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error != "" {
+			return &OAuthError{StatusCode: resp.StatusCode, Code: oauthErr.Error, Description: oauthErr.ErrorDescription}
+		}
+		return fmt.Errorf("token request failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse token response: %w", err)
+	}
+	return nil
+}
+
+type oidcTokenProvider struct {
+	mu           sync.Mutex
+	httpClient   *http.Client
+	cfg          OIDCConfig
+	tokenURL     string
+	accessToken  string
+	refreshToken string
+	expiresAt    time.Time
+}
+
+func NewOIDCTokenProvider(cfg OIDCConfig) (*oidcTokenProvider, error) {
+	if cfg.TokenURL == "" && cfg.Issuer == "" {
+		return nil, fmt.Errorf("either issuer or token url is required")
+	}
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("client id is required")
+	}
+
+	if cfg.AuthMethod == "" {
+		switch {
+		case cfg.ClientSecret != "":
+			cfg.AuthMethod = ClientAuthSecretBasic
+		case cfg.PrivateKey != nil:
+			cfg.AuthMethod = ClientAuthPrivateKeyJWT
+		default:
+			cfg.AuthMethod = ClientAuthNone
+		}
+	}
+	switch cfg.AuthMethod {
+	case ClientAuthSecretBasic, ClientAuthSecretPost:
+		if cfg.ClientSecret == "" {
+			return nil, fmt.Errorf("client secret is required for %s", cfg.AuthMethod)
+		}
+	case ClientAuthPrivateKeyJWT:
+		alg, err := jwtAlgorithm(cfg.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		if alg == AlgorithmHS256 {
+			return nil, fmt.Errorf("private_key_jwt requires an RSA or ECDSA private key")
+		}
+	case ClientAuthNone:
+		if cfg.RefreshToken == "" {
+			return nil, fmt.Errorf("a public client needs a refresh token")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported client auth method %q", cfg.AuthMethod)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient()
+	}
+
+	return &oidcTokenProvider{
+		httpClient:   httpClient,
+		cfg:          cfg,
+		tokenURL:     cfg.TokenURL,
+		refreshToken: cfg.RefreshToken,
+	}, nil
+}
+
+func (p *oidcTokenProvider) Token() (string, error) {
+	return p.TokenContext(context.Background())
+}
+
+// TokenContext returns a valid access token, requesting a new one with ctx if
+// the cached token is about to expire.
+func (p *oidcTokenProvider) TokenContext(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.accessToken != "" && time.Now().Before(p.expiresAt) {
+		return p.accessToken, nil
+	}
+
+	return p.fetchLocked(ctx)
+}
+
+// FetchToken requests a new access token even if the cached one is still
+// valid.
+func (p *oidcTokenProvider) FetchToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.fetchLocked(ctx)
+}
+
+func (p *oidcTokenProvider) fetchLocked(ctx context.Context) (string, error) {
+	if p.tokenURL == "" {
+		md, err := DiscoverOIDC(ctx, p.httpClient, p.cfg.Issuer)
+		if err != nil {
+			return "", err
+		}
+		p.tokenURL = md.TokenEndpoint
+	}
+
+	if p.refreshToken != "" {
+		err := p.requestTokenLocked(ctx, url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {p.refreshToken},
+		})
+		if err == nil {
+			return p.accessToken, nil
+		}
+		if p.cfg.AuthMethod == ClientAuthNone {
+			return "", fmt.Errorf("failed to refresh token: %w", err)
+		}
+		log.Warn().Err(err).Msg("failed to refresh OIDC token, fetching a new access token")
+		p.refreshToken = ""
+	}
+
+	if err := p.requestTokenLocked(ctx, url.Values{"grant_type": {"client_credentials"}}); err != nil {
+		return "", err
+	}
+
+	return p.accessToken, nil
+}
+
+func (p *oidcTokenProvider) requestTokenLocked(ctx context.Context, values url.Values) error {
+	if len(p.cfg.Scopes) > 0 {
+		values.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	}
+	if p.cfg.Audience != "" {
+		values.Set("audience", p.cfg.Audience)
+	}
+
+	header := http.Header{}
+	if err := p.authenticateClient(values, header); err != nil {
+		return err
+	}
+
+	var tokenResp tokenResponse
+	if err := postForm(ctx, p.httpClient, p.tokenURL, values, header, &tokenResp); err != nil {
+		return err
+	}
+	if tokenResp.AccessToken == "" {
+		return fmt.Errorf("token response missing access_token")
+	}
+
+	p.accessToken = tokenResp.AccessToken
+	if tokenResp.RefreshToken != "" {
+		p.refreshToken = tokenResp.RefreshToken
+	}
+	p.expiresAt = tokenResp.expiresAt()
+	return nil
+}
+
+func (p *oidcTokenProvider) authenticateClient(values url.Values, header http.Header) error {
+	switch p.cfg.AuthMethod {
+	case ClientAuthSecretBasic:
+		// RFC 6749 section 2.3.1: credentials are form-encoded before basic auth.
+		credentials := url.QueryEscape(p.cfg.ClientID) + ":" + url.QueryEscape(p.cfg.ClientSecret)
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	case ClientAuthSecretPost:
+		values.Set("client_id", p.cfg.ClientID)
+		values.Set("client_secret", p.cfg.ClientSecret)
+	case ClientAuthPrivateKeyJWT:
+		assertion, err := p.clientAssertion()
+		if err != nil {
+			return err
+		}
+		values.Set("client_id", p.cfg.ClientID)
+		values.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+		values.Set("client_assertion", assertion)
+	case ClientAuthNone:
+		values.Set("client_id", p.cfg.ClientID)
+	}
+	return nil
+}
+
+func (p *oidcTokenProvider) clientAssertion() (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	return signJWT(map[string]any{
+		"iss": p.cfg.ClientID,
+		"sub": p.cfg.ClientID,
+		"aud": p.tokenURL,
+		"jti": hex.EncodeToString(jti),
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+	}, p.cfg.PrivateKey, p.cfg.KeyID)
+}
+
+func (p *oidcTokenProvider) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := p.TokenContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"authorization": fmt.Sprintf("Bearer %s", token),
+	}, nil
+}
+
+func (p *oidcTokenProvider) RequireTransportSecurity() bool {
+	return false
+}
+
+func (p *oidcTokenProvider) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := p.TokenContext(ctx)
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (p *oidcTokenProvider) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		token, err := p.TokenContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
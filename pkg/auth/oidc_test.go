@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newOIDCServer(t *testing.T, handleToken func(r *http.Request) (int, any)) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":         srv.URL + "/",
+			"token_endpoint": srv.URL + "/oauth/token",
+		})
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		status, body := handleToken(r)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+
+	return srv
+}
+
+func TestOIDCTokenProviderClientSecretBasic(t *testing.T) {
+	requests := 0
+	srv := newOIDCServer(t, func(r *http.Request) (int, any) {
+		requests++
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "svc", user)
+		require.Equal(t, "s3cr%2Ft", pass)
+		require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		require.Equal(t, LedgerAPIScope, r.PostForm.Get("scope"))
+		require.Equal(t, "https://canton.example.com", r.PostForm.Get("audience"))
+		return http.StatusOK, map[string]any{"access_token": "tok-1", "expires_in": 300}
+	})
+
+	p, err := NewOIDCTokenProvider(OIDCConfig{
+		Issuer:       srv.URL,
+		ClientID:     "svc",
+		ClientSecret: "s3cr/t",
+		Scopes:       []string{LedgerAPIScope},
+		Audience:     "https://canton.example.com",
+		HTTPClient:   srv.Client(),
+	})
+	require.NoError(t, err)
+
+	for range 2 {
+		token, err := p.TokenContext(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "tok-1", token)
+	}
+	require.Equal(t, 1, requests)
+}
+
+func TestOIDCTokenProviderPrivateKeyJWT(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	srv := newOIDCServer(t, func(r *http.Request) (int, any) {
+		require.Equal(t, "urn:ietf:params:oauth:client-assertion-type:jwt-bearer", r.PostForm.Get("client_assertion_type"))
+		require.Len(t, strings.Split(r.PostForm.Get("client_assertion"), "."), 3)
+		return http.StatusOK, map[string]any{"access_token": "tok-jwt", "expires_in": 300}
+	})
+
+	p, err := NewOIDCTokenProvider(OIDCConfig{
+		TokenURL:   srv.URL + "/oauth/token",
+		ClientID:   "svc",
+		PrivateKey: key,
+		KeyID:      "key-1",
+		HTTPClient: srv.Client(),
+	})
+	require.NoError(t, err)
+
+	token, err := p.Token()
+	require.NoError(t, err)
+	require.Equal(t, "tok-jwt", token)
+}
+
+func TestOIDCTokenProviderRefreshFallsBackToClientCredentials(t *testing.T) {
+	srv := newOIDCServer(t, func(r *http.Request) (int, any) {
+		require.Equal(t, "svc", r.PostForm.Get("client_id"))
+		require.Equal(t, "secret", r.PostForm.Get("client_secret"))
+		if r.PostForm.Get("grant_type") == "refresh_token" {
+			return http.StatusBadRequest, map[string]any{"error": "invalid_grant"}
+		}
+		return http.StatusOK, map[string]any{"access_token": "tok-cc", "expires_in": 300}
+	})
+
+	p, err := NewOIDCTokenProvider(OIDCConfig{
+		TokenURL:     srv.URL + "/oauth/token",
+		ClientID:     "svc",
+		ClientSecret: "secret",
+		AuthMethod:   ClientAuthSecretPost,
+		RefreshToken: "stale",
+		HTTPClient:   srv.Client(),
+	})
+	require.NoError(t, err)
+
+	token, err := p.Token()
+	require.NoError(t, err)
+	require.Equal(t, "tok-cc", token)
+}
+
+func TestOIDCTokenProviderPublicClientRefreshError(t *testing.T) {
+	srv := newOIDCServer(t, func(r *http.Request) (int, any) {
+		return http.StatusBadRequest, map[string]any{"error": "invalid_grant", "error_description": "token revoked"}
+	})
+
+	p, err := NewOIDCTokenProvider(OIDCConfig{
+		Issuer:       srv.URL,
+		ClientID:     "cli",
+		RefreshToken: "revoked",
+		HTTPClient:   srv.Client(),
+	})
+	require.NoError(t, err)
+
+	_, err = p.Token()
+	var oauthErr *OAuthError
+	require.ErrorAs(t, err, &oauthErr)
+	require.Equal(t, "invalid_grant", oauthErr.Code)
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+type PKCEConfig struct {
+	// Issuer is used to discover the endpoints that are not set explicitly.
+	Issuer           string
+	AuthorizationURL string
+	TokenURL         string
+	ClientID         string
+	Scopes           []string
+	Audience         string
+	// RedirectPort is the loopback port the redirect URI points to; zero picks
+	// a free port, which the identity provider must allow for loopback
+	// redirect URIs.
+	RedirectPort int
+	// OpenBrowser opens the authorization URL; defaults to the system browser,
+	// falling back to printing the URL to stderr.
+	OpenBrowser func(authURL string) error
+	HTTPClient  *http.Client
+	// CacheDir defaults to go-daml/tokens in the user config directory.
+	CacheDir     string
+	DisableCache bool
+}
+
+type pkceFlow struct {
+	redirectPort int
+	openBrowser  func(string) error
+}
+
+// NewPKCETokenProvider logs the user in with the authorization code grant and
+// PKCE (RFC 7636), receiving the code on a local loopback redirect.
+func NewPKCETokenProvider(cfg PKCEConfig) (*interactiveTokenProvider, error) {
+	openBrowser := cfg.OpenBrowser
+	if openBrowser == nil {
+		openBrowser = openSystemBrowser
+	}
+
+	return newInteractiveTokenProvider(cfg.Issuer, cfg.ClientID, cfg.Scopes, cfg.Audience, OIDCProviderMetadata{
+		TokenEndpoint:         cfg.TokenURL,
+		AuthorizationEndpoint: cfg.AuthorizationURL,
+	}, cfg.HTTPClient, cfg.CacheDir, cfg.DisableCache, &pkceFlow{
+		redirectPort: cfg.RedirectPort,
+		openBrowser:  openBrowser,
+	})
+}
+
+func (f *pkceFlow) login(ctx context.Context, p *interactiveTokenProvider) (*tokenResponse, error) {
+	if p.endpoints.AuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("identity provider has no authorization endpoint")
+	}
+
+	verifier, err := randomURLSafe(32)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomURLSafe(16)
+	if err != nil {
+		return nil, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(f.redirectPort)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the login redirect: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	authURL, err := url.Parse(p.endpoints.AuthorizationEndpoint)
+	if err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := p.scopeAndAudience(authURL.Query())
+	query.Set("response_type", "code")
+	query.Set("client_id", p.clientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("state", state)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+
+			q := r.URL.Query()
+			var result callbackResult
+			switch {
+			case q.Get("state") != state:
+				result.err = errors.New("login redirect has an unexpected state")
+			case q.Get("error") != "":
+				result.err = &OAuthError{StatusCode: http.StatusBadRequest, Code: q.Get("error"), Description: q.Get("error_description")}
+			case q.Get("code") == "":
+				result.err = errors.New("login redirect has no authorization code")
+			default:
+				result.code = q.Get("code")
+			}
+
+			if result.err != nil {
+				http.Error(w, "Login failed: "+result.err.Error(), http.StatusBadRequest)
+			} else {
+				fmt.Fprintln(w, "Login complete, you can close this window.")
+			}
+			select {
+			case results <- result:
+			default:
+			}
+		}),
+	}
+	go srv.Serve(listener)
+	defer srv.Close()
+
+	if err := f.openBrowser(authURL.String()); err != nil {
+		return nil, fmt.Errorf("failed to open the login page: %w", err)
+	}
+
+	var result callbackResult
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("login was not completed: %w", ctx.Err())
+	case result = <-results:
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	var tokenResp tokenResponse
+	err = p.requestTokenLocked(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {result.code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	}, &tokenResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenResp, nil
+}
+
+func randomURLSafe(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func openSystemBrowser(authURL string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", authURL)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", authURL)
+	default:
+		cmd = exec.Command("xdg-open", authURL)
+	}
+
+	fmt.Fprintf(os.Stderr, "Opening the login page in your browser. If it does not open, visit:\n%s\n", authURL)
+	// the URL is printed either way, so a missing browser is not an error
+	if cmd.Start() == nil {
+		go cmd.Wait()
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TokenFetcher requests a new token, bypassing any cached one. The keycloak,
+// OIDC, device code, PKCE and self-issued providers implement it.
+type TokenFetcher interface {
+	FetchToken(ctx context.Context) (string, error)
+}
+
+// RefreshMetrics receives the outcome of every background refresh, e.g. to
+// export it to Prometheus.
+type RefreshMetrics interface {
+	RefreshSucceeded(latency time.Duration, expiresAt time.Time)
+	RefreshFailed(latency time.Duration, err error)
+}
+
+type RefresherConfig struct {
+	// RefreshBefore is how long before expiry the token is renewed; defaults
+	// to a quarter of the token's lifetime.
+	RefreshBefore time.Duration
+	// Jitter is the upper bound of a random delay subtracted from the refresh
+	// time, so that replicas do not all refresh at once; defaults to a tenth
+	// of the token's lifetime.
+	Jitter time.Duration
+	// DefaultLifetime is assumed for tokens without an exp claim; defaults to
+	// five minutes.
+	DefaultLifetime time.Duration
+	// RetryInterval is the delay after a failed refresh, doubled on every
+	// further failure up to MaxRetryInterval; defaults to 1s and 1m.
+	RetryInterval    time.Duration
+	MaxRetryInterval time.Duration
+	// FetchTimeout bounds a single refresh; defaults to 30s.
+	FetchTimeout time.Duration
+	// OnRefresh is called after every successful refresh. claims is nil for
+	// tokens that are not JWTs.
+	OnRefresh func(claims *Claims)
+	// OnRefreshError is called after every failed refresh.
+	OnRefreshError func(err error, consecutiveFailures int)
+	Metrics        RefreshMetrics
+}
+
+type RefreshStats struct {
+	Refreshes           uint64
+	Failures            uint64
+	ConsecutiveFailures int
+	LastRefresh         time.Time
+	LastError           error
+	ExpiresAt           time.Time
+}
+
+// refreshingTokenProvider renews the token in the background, so the gRPC
+// interceptors only read the current token and never wait for the identity
+// provider.
+type refreshingTokenProvider struct {
+	fetcher TokenFetcher
+	cfg     RefresherConfig
+
+	mu        sync.RWMutex
+	token     string
+	claims    *Claims
+	fetchedAt time.Time
+	expiresAt time.Time
+	stats     RefreshStats
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRefreshingTokenProvider fetches the first token with ctx and then keeps
+// it fresh until ctx is cancelled or Close is called.
+func NewRefreshingTokenProvider(ctx context.Context, fetcher TokenFetcher, cfg RefresherConfig) (*refreshingTokenProvider, error) {
+	if cfg.DefaultLifetime <= 0 {
+		cfg.DefaultLifetime = 5 * time.Minute
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = time.Second
+	}
+	if cfg.MaxRetryInterval <= 0 {
+		cfg.MaxRetryInterval = time.Minute
+	}
+	if cfg.FetchTimeout <= 0 {
+		cfg.FetchTimeout = 30 * time.Second
+	}
+
+	p := &refreshingTokenProvider{
+		fetcher: fetcher,
+		cfg:     cfg,
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	if err := p.refresh(ctx); err != nil {
+		return nil, fmt.Errorf("failed to fetch initial token: %w", err)
+	}
+
+	ctx, p.cancel = context.WithCancel(ctx)
+	go p.run(ctx)
+
+	return p, nil
+}
+
+// Token returns the current token without blocking. It fails once the token
+// has expired because refreshing it kept failing.
+func (p *refreshingTokenProvider) Token() (string, error) {
+	p.mu.RLock()
+	token, expiresAt, lastErr := p.token, p.expiresAt, p.stats.LastError
+	p.mu.RUnlock()
+
+	if time.Now().Before(expiresAt) {
+		return token, nil
+	}
+
+	if lastErr != nil {
+		return "", fmt.Errorf("token expired at %s: %w", expiresAt.Format(time.RFC3339), lastErr)
+	}
+	// the refresh is overdue, e.g. after the host was suspended; failing
+	// refreshes keep their backoff instead
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+	return "", fmt.Errorf("token expired at %s", expiresAt.Format(time.RFC3339))
+}
+
+// Claims returns the claims of the current token, or nil if it is not a JWT.
+func (p *refreshingTokenProvider) Claims() *Claims {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.claims
+}
+
+func (p *refreshingTokenProvider) Stats() RefreshStats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.stats
+}
+
+// Close stops the background refresh.
+func (p *refreshingTokenProvider) Close() {
+	p.cancel()
+	<-p.done
+}
+
+func (p *refreshingTokenProvider) run(ctx context.Context) {
+	defer close(p.done)
+
+	timer := time.NewTimer(p.nextRefresh())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-p.wake:
+			timer.Stop()
+		}
+
+		delay := p.retryDelay()
+		if err := p.refresh(ctx); err == nil {
+			delay = p.nextRefresh()
+		} else if ctx.Err() != nil {
+			return
+		}
+		timer.Reset(delay)
+	}
+}
+
+func (p *refreshingTokenProvider) refresh(ctx context.Context) error {
+	fetchCtx, cancel := context.WithTimeout(ctx, p.cfg.FetchTimeout)
+	defer cancel()
+
+	start := time.Now()
+	token, err := p.fetcher.FetchToken(fetchCtx)
+	if err == nil && token == "" {
+		err = errors.New("token fetcher returned an empty token")
+	}
+	latency := time.Since(start)
+
+	if err != nil {
+		p.mu.Lock()
+		p.stats.Failures++
+		p.stats.ConsecutiveFailures++
+		p.stats.LastError = err
+		failures := p.stats.ConsecutiveFailures
+		p.mu.Unlock()
+
+		log.Warn().Err(err).Int("failures", failures).Msg("failed to refresh token")
+		if p.cfg.Metrics != nil {
+			p.cfg.Metrics.RefreshFailed(latency, err)
+		}
+		if p.cfg.OnRefreshError != nil {
+			p.cfg.OnRefreshError(err, failures)
+		}
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(p.cfg.DefaultLifetime)
+	// opaque tokens have no claims and get the default lifetime
+	claims, _ := ParseClaims(token)
+	if claims != nil && !claims.ExpiresAt.IsZero() {
+		expiresAt = claims.ExpiresAt
+	}
+
+	p.mu.Lock()
+	p.token = token
+	p.claims = claims
+	p.fetchedAt = now
+	p.expiresAt = expiresAt
+	p.stats.Refreshes++
+	p.stats.ConsecutiveFailures = 0
+	p.stats.LastError = nil
+	p.stats.LastRefresh = now
+	p.stats.ExpiresAt = expiresAt
+	p.mu.Unlock()
+
+	if p.cfg.Metrics != nil {
+		p.cfg.Metrics.RefreshSucceeded(latency, expiresAt)
+	}
+	if p.cfg.OnRefresh != nil {
+		p.cfg.OnRefresh(claims)
+	}
+	return nil
+}
+
+func (p *refreshingTokenProvider) nextRefresh() time.Duration {
+	p.mu.RLock()
+	lifetime := p.expiresAt.Sub(p.fetchedAt)
+	remaining := time.Until(p.expiresAt)
+	p.mu.RUnlock()
+
+	before := p.cfg.RefreshBefore
+	if before <= 0 {
+		before = lifetime / 4
+	}
+	jitter := p.cfg.Jitter
+	if jitter <= 0 {
+		jitter = lifetime / 10
+	}
+
+	delay := remaining - before
+	if jitter > 0 {
+		delay -= rand.N(jitter)
+	}
+	return max(delay, time.Second)
+}
+
+func (p *refreshingTokenProvider) retryDelay() time.Duration {
+	p.mu.RLock()
+	failures := p.stats.ConsecutiveFailures
+	p.mu.RUnlock()
+
+	delay := p.cfg.RetryInterval
+	for i := 0; i < failures && delay < p.cfg.MaxRetryInterval; i++ {
+		delay *= 2
+	}
+	return min(delay, p.cfg.MaxRetryInterval)
+}
+
+func (p *refreshingTokenProvider) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := p.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"authorization": fmt.Sprintf("Bearer %s", token),
+	}, nil
+}
+
+func (p *refreshingTokenProvider) RequireTransportSecurity() bool {
+	return false
+}
+
+func (p *refreshingTokenProvider) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := p.Token()
+		if err != nil {
+			return err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (p *refreshingTokenProvider) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		token, err := p.Token()
+		if err != nil {
+			return nil, err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
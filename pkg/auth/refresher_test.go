@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fetcherFunc func(ctx context.Context) (string, error)
+
+func (f fetcherFunc) FetchToken(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+func TestParseClaims(t *testing.T) {
+	signer, err := NewJWTSigner([]byte("secret"), "")
+	require.NoError(t, err)
+	token, err := signer.Mint(LedgerClaims{
+		UserID:    "alice",
+		Audience:  []string{"aud-1", "aud-2"},
+		Scope:     "openid " + LedgerAPIScope,
+		Issuer:    "https://issuer.example.com",
+		ExpiresIn: time.Minute,
+	})
+	require.NoError(t, err)
+
+	claims, err := ParseClaims(token)
+	require.NoError(t, err)
+	require.Equal(t, "alice", claims.Subject)
+	require.Equal(t, "https://issuer.example.com", claims.Issuer)
+	require.Equal(t, []string{"aud-1", "aud-2"}, claims.Audience)
+	require.True(t, claims.HasScope(LedgerAPIScope))
+	require.WithinDuration(t, time.Now().Add(time.Minute), claims.ExpiresAt, 2*time.Second)
+	require.False(t, claims.Expired(time.Now()))
+	require.True(t, claims.Expired(time.Now().Add(2*time.Minute)))
+
+	// Okta style scp list
+	scp, err := signJWT(map[string]any{"sub": "bob", "aud": "ledger", "scp": []string{"a", "b"}}, []byte("secret"), "")
+	require.NoError(t, err)
+	claims, err = ParseClaims(scp)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ledger"}, claims.Audience)
+	require.Equal(t, []string{"a", "b"}, claims.Scopes)
+	require.True(t, claims.ExpiresAt.IsZero())
+
+	_, err = ParseClaims("opaque-token")
+	require.Error(t, err)
+}
+
+func TestRefreshingTokenProviderRenewsBeforeExpiry(t *testing.T) {
+	signer, err := NewJWTSigner([]byte("secret"), "")
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	fetcher := fetcherFunc(func(ctx context.Context) (string, error) {
+		fetches.Add(1)
+		return signer.Mint(LedgerClaims{UserID: "alice", ExpiresIn: 3 * time.Second})
+	})
+
+	var mu sync.Mutex
+	var refreshed []*Claims
+	p, err := NewRefreshingTokenProvider(context.Background(), fetcher, RefresherConfig{
+		RefreshBefore: 2 * time.Second,
+		OnRefresh: func(claims *Claims) {
+			mu.Lock()
+			defer mu.Unlock()
+			refreshed = append(refreshed, claims)
+		},
+	})
+	require.NoError(t, err)
+	defer p.Close()
+
+	first, err := p.Token()
+	require.NoError(t, err)
+	require.Equal(t, "alice", p.Claims().Subject)
+
+	require.Eventually(t, func() bool { return fetches.Load() >= 2 }, 5*time.Second, 20*time.Millisecond)
+	require.Eventually(t, func() bool { return p.Stats().Refreshes >= 2 }, time.Second, 10*time.Millisecond)
+	token, err := p.Token()
+	require.NoError(t, err)
+	require.NotEqual(t, first, token)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.GreaterOrEqual(t, len(refreshed), 2)
+}
+
+func TestRefreshingTokenProviderReportsFailures(t *testing.T) {
+	signer, err := NewJWTSigner([]byte("secret"), "")
+	require.NoError(t, err)
+
+	errIdP := errors.New("identity provider unavailable")
+	var fail atomic.Bool
+	fetcher := fetcherFunc(func(ctx context.Context) (string, error) {
+		if fail.Load() {
+			return "", errIdP
+		}
+		return signer.Mint(LedgerClaims{UserID: "alice", ExpiresIn: 2 * time.Second})
+	})
+
+	failures := make(chan int, 16)
+	metrics := &countingMetrics{}
+	p, err := NewRefreshingTokenProvider(context.Background(), fetcher, RefresherConfig{
+		RefreshBefore: 2 * time.Second,
+		RetryInterval: 50 * time.Millisecond,
+		OnRefreshError: func(err error, consecutiveFailures int) {
+			failures <- consecutiveFailures
+		},
+		Metrics: metrics,
+	})
+	require.NoError(t, err)
+	defer p.Close()
+	fail.Store(true)
+
+	require.Equal(t, 1, <-failures)
+	require.Equal(t, 2, <-failures)
+	require.GreaterOrEqual(t, metrics.failed.Load(), int32(2))
+	require.Equal(t, int32(1), metrics.succeeded.Load())
+
+	// once the token expires the interceptors get the refresh error
+	require.Eventually(t, func() bool {
+		_, err := p.Token()
+		return errors.Is(err, errIdP)
+	}, 5*time.Second, 20*time.Millisecond)
+
+	stats := p.Stats()
+	require.GreaterOrEqual(t, stats.ConsecutiveFailures, 2)
+	require.ErrorIs(t, stats.LastError, errIdP)
+}
+
+func TestRefreshingTokenProviderFailsWithoutInitialToken(t *testing.T) {
+	_, err := NewRefreshingTokenProvider(context.Background(), fetcherFunc(func(ctx context.Context) (string, error) {
+		return "", errors.New("boom")
+	}), RefresherConfig{})
+	require.Error(t, err)
+}
+
+type countingMetrics struct {
+	succeeded atomic.Int32
+	failed    atomic.Int32
+}
+
+func (m *countingMetrics) RefreshSucceeded(time.Duration, time.Time) { m.succeeded.Add(1) }
+
+func (m *countingMetrics) RefreshFailed(time.Duration, error) { m.failed.Add(1) }
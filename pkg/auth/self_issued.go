@@ -0,0 +1,268 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const defaultSelfIssuedTokenLifetime = time.Hour
+
+// ParticipantAudience returns the audience Canton expects in audience-based
+// tokens for the given participant.
+func ParticipantAudience(participantID string) string {
+	return "https://daml.com/jwt/aud/participant/" + participantID
+}
+
+// JWTSigner mints Ledger API tokens locally, e.g. for sandboxes and tests
+// running with unsafe-jwt-hmac-256 or jwt-jwks authorization.
+type JWTSigner struct {
+	key   any
+	keyID string
+	alg   string
+}
+
+// NewJWTSigner creates a signer from a []byte HMAC secret (HS256), an
+// *rsa.PrivateKey (RS256) or a P-256 *ecdsa.PrivateKey (ES256). An empty keyID
+// is derived from the public key.
+func NewJWTSigner(key any, keyID string) (*JWTSigner, error) {
+	alg, err := jwtAlgorithm(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyID == "" && alg != AlgorithmHS256 {
+		der, err := x509.MarshalPKIXPublicKey(publicKey(key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode public key: %w", err)
+		}
+		sum := sha256.Sum256(der)
+		keyID = base64.RawURLEncoding.EncodeToString(sum[:12])
+	}
+
+	return &JWTSigner{
+		key:   key,
+		keyID: keyID,
+		alg:   alg,
+	}, nil
+}
+
+// GenerateJWTSigner creates a signer with a fresh random key for alg.
+func GenerateJWTSigner(alg string) (*JWTSigner, error) {
+	var key any
+	var err error
+	switch alg {
+	case AlgorithmHS256:
+		secret := make([]byte, 32)
+		_, err = rand.Read(secret)
+		key = secret
+	case AlgorithmRS256:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmES256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", alg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s key: %w", alg, err)
+	}
+
+	return NewJWTSigner(key, "")
+}
+
+func (s *JWTSigner) Algorithm() string {
+	return s.alg
+}
+
+func (s *JWTSigner) KeyID() string {
+	return s.keyID
+}
+
+type LedgerClaims struct {
+	// UserID is the Ledger API user the token authenticates, sent as sub.
+	UserID string
+	// Audience is ParticipantAudience(participantID) for audience-based
+	// tokens, or the participant's configured target audience.
+	Audience []string
+	// Scope is LedgerAPIScope for scope-based tokens.
+	Scope  string
+	Issuer string
+	// ExpiresIn defaults to one hour.
+	ExpiresIn time.Duration
+}
+
+// Mint signs a token for the claims.
+func (s *JWTSigner) Mint(claims LedgerClaims) (string, error) {
+	if claims.UserID == "" {
+		return "", fmt.Errorf("user id is required")
+	}
+
+	expiresIn := claims.ExpiresIn
+	if expiresIn <= 0 {
+		expiresIn = defaultSelfIssuedTokenLifetime
+	}
+	now := time.Now()
+
+	payload := map[string]any{
+		"sub": claims.UserID,
+		"iat": now.Unix(),
+		"exp": now.Add(expiresIn).Unix(),
+	}
+	switch len(claims.Audience) {
+	case 0:
+	case 1:
+		payload["aud"] = claims.Audience[0]
+	default:
+		payload["aud"] = claims.Audience
+	}
+	if claims.Scope != "" {
+		payload["scope"] = claims.Scope
+	}
+	if claims.Issuer != "" {
+		payload["iss"] = claims.Issuer
+	}
+
+	return signJWT(payload, s.key, s.keyID)
+}
+
+// JWKS returns the JSON Web Key Set with the signer's public key, to be served
+// at the URL of a jwt-jwks auth service. HMAC secrets have no public key.
+func (s *JWTSigner) JWKS() ([]byte, error) {
+	jwk := map[string]string{
+		"kid": s.keyID,
+		"use": "sig",
+		"alg": s.alg,
+	}
+	switch k := s.key.(type) {
+	case *rsa.PrivateKey:
+		jwk["kty"] = "RSA"
+		jwk["n"] = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk["e"] = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	case *ecdsa.PrivateKey:
+		x, y := make([]byte, 32), make([]byte, 32)
+		k.X.FillBytes(x)
+		k.Y.FillBytes(y)
+		jwk["kty"] = "EC"
+		jwk["crv"] = "P-256"
+		jwk["x"] = base64.RawURLEncoding.EncodeToString(x)
+		jwk["y"] = base64.RawURLEncoding.EncodeToString(y)
+	default:
+		return nil, fmt.Errorf("%s keys cannot be published in a JWKS", s.alg)
+	}
+
+	return json.Marshal(map[string]any{"keys": []any{jwk}})
+}
+
+func publicKey(key any) any {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &k.PublicKey
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey
+	default:
+		return nil
+	}
+}
+
+// selfIssuedTokenProvider mints a new token shortly before the previous one
+// expires.
+type selfIssuedTokenProvider struct {
+	mu          sync.Mutex
+	signer      *JWTSigner
+	claims      LedgerClaims
+	accessToken string
+	expiresAt   time.Time
+}
+
+func NewSelfIssuedTokenProvider(signer *JWTSigner, claims LedgerClaims) *selfIssuedTokenProvider {
+	return &selfIssuedTokenProvider{
+		signer: signer,
+		claims: claims,
+	}
+}
+
+func (p *selfIssuedTokenProvider) Token() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.accessToken != "" && time.Now().Before(p.expiresAt) {
+		return p.accessToken, nil
+	}
+
+	return p.mintLocked()
+}
+
+// FetchToken mints a new token even if the current one is still valid.
+func (p *selfIssuedTokenProvider) FetchToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.mintLocked()
+}
+
+func (p *selfIssuedTokenProvider) mintLocked() (string, error) {
+	expiresIn := p.claims.ExpiresIn
+	if expiresIn <= 0 {
+		expiresIn = defaultSelfIssuedTokenLifetime
+	}
+	token, err := p.signer.Mint(p.claims)
+	if err != nil {
+		return "", err
+	}
+
+	p.accessToken = token
+	p.expiresAt = time.Now().Add(expiresIn * 9 / 10)
+	return p.accessToken, nil
+}
+
+func (p *selfIssuedTokenProvider) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := p.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"authorization": fmt.Sprintf("Bearer %s", token),
+	}, nil
+}
+
+func (p *selfIssuedTokenProvider) RequireTransportSecurity() bool {
+	return false
+}
+
+func (p *selfIssuedTokenProvider) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := p.Token()
+		if err != nil {
+			return err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (p *selfIssuedTokenProvider) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		token, err := p.Token()
+		if err != nil {
+			return nil, err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
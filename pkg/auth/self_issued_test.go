@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// verifyJWT checks the signature against the signer's JWKS (or secret) and
+// returns the header and claims.
+func verifyJWT(t *testing.T, signer *JWTSigner, token string) (map[string]any, map[string]any) {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	decode := func(s string) []byte {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+	var header, claims map[string]any
+	require.NoError(t, json.Unmarshal(decode(parts[0]), &header))
+	require.NoError(t, json.Unmarshal(decode(parts[1]), &claims))
+
+	signingInput := parts[0] + "." + parts[1]
+	digest := sha256.Sum256([]byte(signingInput))
+	signature := decode(parts[2])
+
+	if signer.Algorithm() == AlgorithmHS256 {
+		mac := hmac.New(sha256.New, signer.key.([]byte))
+		mac.Write([]byte(signingInput))
+		require.True(t, hmac.Equal(mac.Sum(nil), signature))
+		return header, claims
+	}
+
+	jwks, err := signer.JWKS()
+	require.NoError(t, err)
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(jwks, &set))
+	require.Len(t, set.Keys, 1)
+	jwk := set.Keys[0]
+	require.Equal(t, header["kid"], jwk["kid"])
+
+	switch jwk["kty"] {
+	case "RSA":
+		pub := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(decode(jwk["n"])),
+			E: int(new(big.Int).SetBytes(decode(jwk["e"])).Int64()),
+		}
+		require.NoError(t, rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature))
+	case "EC":
+		pub := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(decode(jwk["x"])),
+			Y:     new(big.Int).SetBytes(decode(jwk["y"])),
+		}
+		require.Len(t, signature, 64)
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		require.True(t, ecdsa.Verify(pub, digest[:], r, s))
+	default:
+		t.Fatalf("unexpected key type %q", jwk["kty"])
+	}
+
+	return header, claims
+}
+
+func TestJWTSignerMint(t *testing.T) {
+	for _, alg := range []string{AlgorithmHS256, AlgorithmRS256, AlgorithmES256} {
+		t.Run(alg, func(t *testing.T) {
+			signer, err := GenerateJWTSigner(alg)
+			require.NoError(t, err)
+
+			token, err := signer.Mint(LedgerClaims{
+				UserID:    "alice",
+				Audience:  []string{ParticipantAudience("participant1::1220abcd")},
+				Scope:     LedgerAPIScope,
+				ExpiresIn: 5 * time.Minute,
+			})
+			require.NoError(t, err)
+
+			header, claims := verifyJWT(t, signer, token)
+			require.Equal(t, alg, header["alg"])
+			require.Equal(t, "alice", claims["sub"])
+			require.Equal(t, "https://daml.com/jwt/aud/participant/participant1::1220abcd", claims["aud"])
+			require.Equal(t, LedgerAPIScope, claims["scope"])
+			require.InDelta(t, time.Now().Add(5*time.Minute).Unix(), claims["exp"], 5)
+		})
+	}
+}
+
+func TestJWTSignerJWKSRejectsHMAC(t *testing.T) {
+	signer, err := NewJWTSigner([]byte("secret"), "")
+	require.NoError(t, err)
+
+	_, err = signer.JWKS()
+	require.Error(t, err)
+}
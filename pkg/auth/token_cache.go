@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type CachedToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// FileTokenCache stores the tokens of one login in a file only readable by the
+// current user.
+type FileTokenCache struct {
+	path string
+}
+
+// NewFileTokenCache returns a cache for the given login under dir, which
+// defaults to go-daml/tokens in the user config directory.
+func NewFileTokenCache(dir, issuer, clientID string, scopes []string, audience string) (*FileTokenCache, error) {
+	if dir == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate user config directory: %w", err)
+		}
+		dir = filepath.Join(configDir, "go-daml", "tokens")
+	}
+
+	key := sha256.Sum256([]byte(strings.Join([]string{issuer, clientID, strings.Join(scopes, " "), audience}, "\n")))
+	return &FileTokenCache{
+		path: filepath.Join(dir, hex.EncodeToString(key[:16])+".json"),
+	}, nil
+}
+
+func (c *FileTokenCache) Path() string {
+	return c.path
+}
+
+// Load returns nil without an error if nothing is cached yet.
+func (c *FileTokenCache) Load() (*CachedToken, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token cache: %w", err)
+	}
+
+	var token CachedToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token cache %s: %w", c.path, err)
+	}
+	return &token, nil
+}
+
+func (c *FileTokenCache) Save(token *CachedToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create token cache directory: %w", err)
+	}
+
+	// write and rename so concurrent readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".token-*")
+	if err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	return nil
+}
+
+func (c *FileTokenCache) Clear() error {
+	err := os.Remove(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
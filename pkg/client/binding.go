@@ -22,6 +22,16 @@ type DamlBindingClient struct {
 	CommandInspectionMng         admin.CommandInspection
 	IdentityProviderMng          admin.IdentityProviderConfig
 	TrafficControl               admin.TrafficControl
+	DarMng                       admin.DarManagement
+	SynchronizerConnectivity     admin.SynchronizerConnectivity
+	RepairMng                    admin.ParticipantRepair
+	CantonPruningMng             admin.CantonPruning
+	InspectionMng                admin.ParticipantInspection
+	Diagnostics                  admin.Diagnostics
+	VaultMng                     admin.Vault
+	PartyReplication             admin.PartyReplication
+	ResourceMng                  admin.ResourceManagement
+	ReplicationMng               admin.ParticipantReplication
 	CommandCompletion            ledger.CommandCompletion
 	CommandService               ledger.CommandService
 	CommandSubmission            ledger.CommandSubmission
@@ -35,6 +45,8 @@ type DamlBindingClient struct {
 	TimeService                  testing.TimeService
 	TopologyManagerWrite         topology.TopologyManagerWrite
 	TopologyManagerRead          topology.TopologyManagerRead
+	TopologyAggregation          topology.TopologyAggregation
+	IdentityInitialization       topology.IdentityInitialization
 }
 
 func NewDamlBindingClient(client *DamlClient, conn *Connection) *DamlBindingClient {
@@ -52,6 +64,16 @@ func NewDamlBindingClient(client *DamlClient, conn *Connection) *DamlBindingClie
 		CommandInspectionMng:         admin.NewCommandInspectionClient(grpc),
 		IdentityProviderMng:          admin.NewIdentityProviderConfigClient(grpc),
 		TrafficControl:               admin.NewTrafficControlClient(adminGrpc),
+		DarMng:                       admin.NewDarManagementClient(adminGrpc),
+		SynchronizerConnectivity:     admin.NewSynchronizerConnectivityClient(adminGrpc),
+		RepairMng:                    admin.NewParticipantRepairClient(adminGrpc),
+		CantonPruningMng:             admin.NewCantonPruningClient(adminGrpc),
+		InspectionMng:                admin.NewParticipantInspectionClient(adminGrpc),
+		Diagnostics:                  admin.NewDiagnosticsClient(adminGrpc),
+		VaultMng:                     admin.NewVaultClient(adminGrpc),
+		PartyReplication:             admin.NewPartyReplicationClient(adminGrpc),
+		ResourceMng:                  admin.NewResourceManagementClient(adminGrpc),
+		ReplicationMng:               admin.NewParticipantReplicationClient(adminGrpc),
 		CommandCompletion:            ledger.NewCommandCompletionClient(grpc),
 		CommandService:               ledger.NewCommandServiceClient(grpc),
 		CommandSubmission:            ledger.NewCommandSubmissionClient(grpc),
@@ -65,6 +87,8 @@ func NewDamlBindingClient(client *DamlClient, conn *Connection) *DamlBindingClie
 		TimeService:                  testing.NewTimeServiceClient(grpc),
 		TopologyManagerWrite:         topology.NewTopologyManagerWriteClient(adminGrpc),
 		TopologyManagerRead:          topology.NewTopologyManagerReadClient(adminGrpc),
+		TopologyAggregation:          topology.NewTopologyAggregationClient(adminGrpc),
+		IdentityInitialization:       topology.NewIdentityInitializationClient(adminGrpc),
 	}
 }
 
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+)
+
+type BootstrapIdentityRequest struct {
+	// Identifier is the node name, e.g. "participant1".
+	Identifier string
+	// NamespaceKey is the root key of the node's namespace. It only signs the
+	// root certificate and the delegation to a key generated on the node.
+	NamespaceKey topology.Signer
+	// SigningKeySpec defaults to Curve25519.
+	SigningKeySpec model.SigningKeySpec
+	// EncryptionKeySpec defaults to ECP256.
+	EncryptionKeySpec model.EncryptionKeySpec
+	// SynchronizerID, if set, also issues the synchronizer trust certificate.
+	SynchronizerID string
+}
+
+type BootstrapIdentityResult struct {
+	UID string
+	// NamespaceKey is the node key the root key delegated the namespace to.
+	NamespaceKey     *model.KeyDescriptor
+	SigningKey       *model.KeyDescriptor
+	SequencerAuthKey *model.KeyDescriptor
+	EncryptionKey    *model.KeyDescriptor
+}
+
+// BootstrapIdentity initializes a participant configured for manual identity
+// with an externally held namespace key: it generates the node keys in the
+// vault, initializes the node with the root certificate and a delegation to the
+// node's own namespace key, and authorizes the owner-to-key mapping and,
+// optionally, the synchronizer trust certificate.
+func (c *DamlBindingClient) BootstrapIdentity(ctx context.Context, req *BootstrapIdentityRequest) (*BootstrapIdentityResult, error) {
+	if req.Identifier == "" || req.NamespaceKey == nil {
+		return nil, errors.New("identifier and namespace key are required")
+	}
+
+	id, err := c.IdentityInitialization.GetID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node ID: %w", err)
+	}
+	if id.Initialized {
+		return nil, fmt.Errorf("node is already initialized as %s", id.UniqueIdentifier)
+	}
+
+	signingKeySpec := req.SigningKeySpec
+	if signingKeySpec == model.SigningKeySpecUnspecified {
+		signingKeySpec = model.SigningKeySpecCurve25519
+	}
+	encryptionKeySpec := req.EncryptionKeySpec
+	if encryptionKeySpec == model.EncryptionKeySpecUnspecified {
+		encryptionKeySpec = model.EncryptionKeySpecECP256
+	}
+
+	result := &BootstrapIdentityResult{}
+	signingKeys := []struct {
+		key   **model.KeyDescriptor
+		name  string
+		usage model.SigningKeyUsage
+	}{
+		{&result.NamespaceKey, "namespace", model.SigningKeyUsageNamespace},
+		{&result.SigningKey, "signing", model.SigningKeyUsageProtocol},
+		{&result.SequencerAuthKey, "sequencer-auth", model.SigningKeyUsageSequencerAuthentication},
+	}
+	for _, k := range signingKeys {
+		*k.key, err = c.VaultMng.GenerateSigningKey(ctx, &model.GenerateSigningKeyRequest{
+			Name:    req.Identifier + "-" + k.name,
+			KeySpec: signingKeySpec,
+			Usage:   []model.SigningKeyUsage{k.usage},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s key: %w", k.name, err)
+		}
+	}
+	result.EncryptionKey, err = c.VaultMng.GenerateEncryptionKey(ctx, &model.GenerateEncryptionKeyRequest{
+		Name:    req.Identifier + "-encryption",
+		KeySpec: encryptionKeySpec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate encryption key: %w", err)
+	}
+
+	namespace := req.NamespaceKey.Fingerprint()
+	delegations := make([]*model.SignedTopologyTransaction, 0, 2)
+	for _, mapping := range []*model.NamespaceDelegationMapping{
+		{Namespace: namespace, TargetKey: req.NamespaceKey.PublicKey(model.SigningKeyUsageNamespace), IsRootDelegation: true},
+		{Namespace: namespace, TargetKey: keyDescriptorToPublicKey(result.NamespaceKey)},
+	} {
+		tx, err := topology.BuildTransaction(model.OperationAddReplace, 1, mapping)
+		if err != nil {
+			return nil, err
+		}
+		signed, err := topology.SignTransaction(tx, req.NamespaceKey)
+		if err != nil {
+			return nil, err
+		}
+		delegations = append(delegations, signed)
+	}
+
+	err = c.IdentityInitialization.InitID(ctx, &model.InitIDRequest{
+		Identifier:           req.Identifier,
+		Namespace:            namespace,
+		NamespaceDelegations: delegations,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize node ID: %w", err)
+	}
+	result.UID = req.Identifier + "::" + namespace
+
+	store := &model.StoreID{Value: "authorized"}
+	mappings := []model.TopologyMapping{
+		&model.OwnerToKeyMapping{
+			Member: "PAR::" + result.UID,
+			SigningKeys: []model.PublicKey{
+				keyDescriptorToPublicKey(result.SigningKey),
+				keyDescriptorToPublicKey(result.SequencerAuthKey),
+			},
+			EncryptionKeys: []model.PublicKey{keyDescriptorToPublicKey(result.EncryptionKey)},
+		},
+	}
+	if req.SynchronizerID != "" {
+		mappings = append(mappings, &model.SynchronizerTrustCertificateMapping{
+			ParticipantUID: result.UID,
+			SynchronizerID: req.SynchronizerID,
+		})
+	}
+	for _, mapping := range mappings {
+		_, err = c.TopologyManagerWrite.Authorize(ctx, &model.AuthorizeRequest{
+			Proposal: &model.TopologyTransactionProposal{
+				Operation: model.OperationAddReplace,
+				Mapping:   mapping,
+				Serial:    1,
+			},
+			MustFullyAuthorize: true,
+			Store:              store,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to authorize %T: %w", mapping, err)
+		}
+	}
+
+	return result, nil
+}
+
+func keyDescriptorToPublicKey(key *model.KeyDescriptor) model.PublicKey {
+	usage := make([]int32, len(key.Usage))
+	for i, u := range key.Usage {
+		usage[i] = int32(u)
+	}
+
+	publicKey := model.PublicKey{
+		Format: int32(key.Format),
+		Key:    key.PublicKey,
+		ID:     key.Fingerprint,
+		Usage:  usage,
+	}
+	if key.Purpose == model.KeyPurposeEncryption {
+		publicKey.KeySpec = int32(key.EncryptionKeySpec)
+	} else {
+		publicKey.KeySpec = int32(key.SigningKeySpec)
+	}
+
+	return publicKey
+}
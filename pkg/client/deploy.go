@@ -0,0 +1,225 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/dar"
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+const (
+	defaultDeployTimeout      = time.Minute
+	defaultDeployPollInterval = time.Second
+)
+
+type DeployDarOptions struct {
+	SubmissionID string
+	// Timeout bounds the wait for vetting; defaults to one minute.
+	Timeout      time.Duration
+	PollInterval time.Duration
+	// UnvetSuperseded unvets older versions of the main package, by package
+	// name and version, once the new version is vetted.
+	UnvetSuperseded bool
+}
+
+type DeployDarResult struct {
+	Dar *dar.Info
+	// VettedOn lists the synchronizers the main package is vetted on.
+	VettedOn []string
+	// Unvetted lists the package IDs of superseded versions that were unvetted.
+	Unvetted []string
+}
+
+// DeployDar validates and uploads a DAR, then waits until its main package is
+// vetted by this participant on every connected synchronizer, so commands
+// using the new package can be submitted right away.
+func (c *DamlBindingClient) DeployDar(ctx context.Context, data []byte, opts *DeployDarOptions) (*DeployDarResult, error) {
+	if opts == nil {
+		opts = &DeployDarOptions{}
+	}
+
+	info, err := dar.Inspect(data)
+	if err != nil {
+		return nil, err
+	}
+	result := &DeployDarResult{Dar: info}
+
+	if err := c.PackageMng.ValidateDarFile(ctx, data, opts.SubmissionID); err != nil {
+		return nil, fmt.Errorf("dar validation failed: %w", err)
+	}
+	if err := c.PackageMng.UploadDarFile(ctx, data, opts.SubmissionID); err != nil {
+		return nil, fmt.Errorf("failed to upload dar: %w", err)
+	}
+
+	participantID, err := c.PartyMng.GetParticipantID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant ID: %w", err)
+	}
+
+	connected, err := c.StateService.GetConnectedSynchronizers(ctx, &model.GetConnectedSynchronizersRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connected synchronizers: %w", err)
+	}
+	if len(connected.ConnectedSynchronizers) == 0 {
+		return nil, errors.New("participant is not connected to any synchronizer")
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultDeployTimeout
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	vetted, err := c.waitForVetting(waitCtx, participantID, info.MainPackageID, connected.ConnectedSynchronizers, opts.PollInterval)
+	if err != nil {
+		return nil, err
+	}
+	for _, vp := range vetted {
+		result.VettedOn = append(result.VettedOn, vp.SynchronizerID)
+	}
+
+	if opts.UnvetSuperseded {
+		result.Unvetted, err = c.unvetSuperseded(ctx, participantID, info.MainPackageID, vetted)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// waitForVetting polls the vetted packages of the participant until packageID
+// shows up on every synchronizer and returns the vetting state per synchronizer.
+func (c *DamlBindingClient) waitForVetting(ctx context.Context, participantID, packageID string, synchronizers []*model.ConnectedSynchronizer, interval time.Duration) ([]*model.VettedPackages, error) {
+	if interval <= 0 {
+		interval = defaultDeployPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		vetted, err := c.listVettedPackages(ctx, &model.ListVettedPackagesRequest{
+			PackageMetadataFilter: &model.PackageMetadataFilter{PackageIDs: []string{packageID}},
+			TopologyStateFilter:   &model.TopologyStateFilter{ParticipantIDs: []string{participantID}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list vetted packages: %w", err)
+		}
+
+		bySynchronizer := make(map[string]*model.VettedPackages)
+		for _, vp := range vetted {
+			if slices.ContainsFunc(vp.Packages, func(p *model.VettedPackage) bool { return p.PackageID == packageID }) {
+				bySynchronizer[vp.SynchronizerID] = vp
+			}
+		}
+
+		var pending []string
+		result := make([]*model.VettedPackages, 0, len(synchronizers))
+		for _, s := range synchronizers {
+			vp, ok := bySynchronizer[s.SynchronizerID]
+			if !ok {
+				pending = append(pending, s.SynchronizerID)
+				continue
+			}
+			result = append(result, vp)
+		}
+		if len(pending) == 0 {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("package %s not vetted on %s: %w", packageID, strings.Join(pending, ", "), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *DamlBindingClient) unvetSuperseded(ctx context.Context, participantID, packageID string, vetted []*model.VettedPackages) ([]string, error) {
+	var unvetted []string
+	for _, vp := range vetted {
+		idx := slices.IndexFunc(vp.Packages, func(p *model.VettedPackage) bool { return p.PackageID == packageID })
+		current := vp.Packages[idx]
+		if current.PackageName == "" {
+			continue
+		}
+
+		all, err := c.listVettedPackages(ctx, &model.ListVettedPackagesRequest{
+			PackageMetadataFilter: &model.PackageMetadataFilter{PackageNamePrefixes: []string{current.PackageName}},
+			TopologyStateFilter: &model.TopologyStateFilter{
+				ParticipantIDs:  []string{participantID},
+				SynchronizerIDs: []string{vp.SynchronizerID},
+			},
+		})
+		if err != nil {
+			return unvetted, fmt.Errorf("failed to list vetted packages: %w", err)
+		}
+
+		var refs []*model.VettedPackagesRef
+		for _, other := range all {
+			for _, p := range other.Packages {
+				if p.PackageName == current.PackageName && compareVersions(p.PackageVersion, current.PackageVersion) < 0 {
+					refs = append(refs, &model.VettedPackagesRef{PackageID: p.PackageID})
+				}
+			}
+		}
+		if len(refs) == 0 {
+			continue
+		}
+
+		_, err = c.PackageMng.UpdateVettedPackages(ctx, &model.UpdateVettedPackagesRequest{
+			Changes:        []*model.VettedPackagesChange{{Unvet: &model.VettedPackagesUnvet{Packages: refs}}},
+			SynchronizerID: vp.SynchronizerID,
+		})
+		if err != nil {
+			return unvetted, fmt.Errorf("failed to unvet superseded packages on %s: %w", vp.SynchronizerID, err)
+		}
+		for _, ref := range refs {
+			if !slices.Contains(unvetted, ref.PackageID) {
+				unvetted = append(unvetted, ref.PackageID)
+			}
+		}
+	}
+
+	return unvetted, nil
+}
+
+func (c *DamlBindingClient) listVettedPackages(ctx context.Context, req *model.ListVettedPackagesRequest) ([]*model.VettedPackages, error) {
+	var result []*model.VettedPackages
+	for {
+		resp, err := c.PackageService.ListVettedPackages(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.VettedPackages...)
+		if resp.NextPageToken == "" {
+			return result, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+// compareVersions compares dotted Daml package versions numerically.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
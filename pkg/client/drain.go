@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const defaultDrainPollInterval = time.Second
+
+type DrainOptions struct {
+	// SynchronizerIDs limits the in-flight check; all connected synchronizers
+	// are checked when empty.
+	SynchronizerIDs []string
+	PollInterval    time.Duration
+}
+
+// Drain takes a participant replica out of service: it sets the replica
+// passive, waits until no submissions or transactions are in flight on any
+// synchronizer, and confirms that the node now reports itself as passive.
+// Bound the wait with a context deadline.
+func (c *DamlBindingClient) Drain(ctx context.Context, opts *DrainOptions) error {
+	if opts == nil {
+		opts = &DrainOptions{}
+	}
+
+	synchronizerIDs := opts.SynchronizerIDs
+	if len(synchronizerIDs) == 0 {
+		connected, err := c.SynchronizerConnectivity.ListConnectedSynchronizers(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list connected synchronizers: %w", err)
+		}
+		for _, s := range connected {
+			synchronizerIDs = append(synchronizerIDs, s.SynchronizerID)
+		}
+	}
+
+	if err := c.ReplicationMng.SetPassive(ctx); err != nil {
+		return fmt.Errorf("failed to set participant passive: %w", err)
+	}
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultDrainPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for _, synchronizerID := range synchronizerIDs {
+		for {
+			count, err := c.InspectionMng.CountInFlight(ctx, synchronizerID)
+			if err != nil {
+				return fmt.Errorf("failed to count in-flight requests on %s: %w", synchronizerID, err)
+			}
+			if count.PendingSubmissions == 0 && count.PendingTransactions == 0 {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%d submissions and %d transactions still in flight on %s: %w",
+					count.PendingSubmissions, count.PendingTransactions, synchronizerID, ctx.Err())
+			case <-ticker.C:
+			}
+		}
+	}
+
+	status, err := c.Diagnostics.ParticipantStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to confirm participant status: %w", err)
+	}
+	if status.Active {
+		return errors.New("participant still reports itself as active after drain")
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/admin"
+)
+
+type HealthCheckOptions struct {
+	// PingParticipant is a party hosted on the participant to ping; the ping
+	// check is skipped when it is empty.
+	PingParticipant string
+	PingTimeout     time.Duration
+	// SkipTraffic disables the traffic state check, e.g. on synchronizers
+	// without traffic control.
+	SkipTraffic bool
+}
+
+type HealthReport struct {
+	LedgerAPIVersion       string
+	ConnectedSynchronizers []*model.ConnectedSynchronizerStatus
+	ParticipantStatus      *model.ParticipantStatus
+	Ping                   *model.PingResult
+	Traffic                map[string]*admin.TrafficState
+	Errors                 []error
+}
+
+func (r *HealthReport) Healthy() bool {
+	return len(r.Errors) == 0
+}
+
+func (r *HealthReport) Err() error {
+	return errors.Join(r.Errors...)
+}
+
+// HealthCheck runs every diagnostic check against the participant and collects
+// the results. All checks are attempted even if earlier ones fail; the returned
+// error joins every failure and is nil only when the participant is usable.
+func (c *DamlBindingClient) HealthCheck(ctx context.Context, opts *HealthCheckOptions) (*HealthReport, error) {
+	if opts == nil {
+		opts = &HealthCheckOptions{}
+	}
+
+	report := &HealthReport{
+		Traffic: make(map[string]*admin.TrafficState),
+	}
+	fail := func(format string, args ...any) {
+		report.Errors = append(report.Errors, fmt.Errorf(format, args...))
+	}
+
+	version, err := c.VersionService.GetLedgerAPIVersion(ctx, &model.GetLedgerAPIVersionRequest{})
+	if err != nil {
+		fail("ledger API version: %w", err)
+	} else {
+		report.LedgerAPIVersion = version.Version
+	}
+
+	status, err := c.Diagnostics.ParticipantStatus(ctx)
+	switch {
+	case err != nil:
+		fail("participant status: %w", err)
+	case !status.Initialized:
+		report.ParticipantStatus = status
+		fail("participant status: not initialized, waiting for external input %d", status.WaitingForExternalInput)
+	default:
+		report.ParticipantStatus = status
+		if !status.Active {
+			fail("participant status: node is passive")
+		}
+		for _, component := range status.Components {
+			if component.Health == model.ComponentHealthFailed || component.Health == model.ComponentHealthFatal {
+				fail("participant status: component %s is unhealthy: %s", component.Name, component.Description)
+			}
+		}
+	}
+
+	synchronizers, err := c.SynchronizerConnectivity.ListConnectedSynchronizers(ctx)
+	switch {
+	case err != nil:
+		fail("connected synchronizers: %w", err)
+	case len(synchronizers) == 0:
+		fail("connected synchronizers: participant is not connected to any synchronizer")
+	default:
+		report.ConnectedSynchronizers = synchronizers
+		for _, s := range synchronizers {
+			if !s.Healthy {
+				fail("connected synchronizers: %s is unhealthy", s.SynchronizerAlias)
+			}
+		}
+	}
+
+	if opts.PingParticipant != "" {
+		ping, err := c.Diagnostics.Ping(ctx, &model.PingRequest{
+			TargetParties: []string{opts.PingParticipant},
+			Timeout:       opts.PingTimeout,
+		})
+		switch {
+		case err != nil:
+			fail("ping %s: %w", opts.PingParticipant, err)
+		case !ping.Success:
+			report.Ping = ping
+			fail("ping %s: %s", opts.PingParticipant, ping.Reason)
+		default:
+			report.Ping = ping
+		}
+	}
+
+	if !opts.SkipTraffic {
+		for _, s := range report.ConnectedSynchronizers {
+			state, err := c.TrafficControl.GetState(ctx, s.SynchronizerID)
+			if err != nil {
+				fail("traffic state on %s: %w", s.SynchronizerAlias, err)
+				continue
+			}
+			report.Traffic[s.SynchronizerID] = state
+		}
+	}
+
+	return report, report.Err()
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+const defaultPartyReplicationPollInterval = 2 * time.Second
+
+type ReplicatePartyRequest struct {
+	PartyID        string
+	SynchronizerID string
+	Permission     model.ParticipantPermission
+	// SkipProposal leaves authorizing the PartyToParticipant proposal to the
+	// caller and is required for externally signed parties. The proposal must
+	// add the target participant with Onboarding set, at the next serial, and
+	// be authorized by the party and the target participant.
+	SkipProposal bool
+	PollInterval time.Duration
+	// OnProgress, if set, is called with every status polled from the target.
+	OnProgress func(*model.PartyReplicationStatus)
+}
+
+// ReplicateParty adds a party hosted on this participant to the target
+// participant using online party replication. It proposes the updated
+// PartyToParticipant mapping on this participant and authorizes it on the
+// target, which has to agree to host the party, starts the add-party job on
+// both participants and polls the target until the job completes or fails.
+func (c *DamlBindingClient) ReplicateParty(ctx context.Context, target *DamlBindingClient, req *ReplicatePartyRequest) (*model.PartyReplicationStatus, error) {
+	if req.PartyID == "" || req.SynchronizerID == "" {
+		return nil, errors.New("party ID and synchronizer ID are required")
+	}
+
+	sourceUID, err := c.participantUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source participant ID: %w", err)
+	}
+	targetUID, err := target.participantUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target participant ID: %w", err)
+	}
+
+	store := &model.StoreID{Value: "synchronizer:" + req.SynchronizerID}
+	current, err := c.TopologyManagerRead.ListPartyToParticipant(ctx, &model.ListPartyToParticipantRequest{
+		BaseQuery:   &model.BaseQuery{Store: store},
+		FilterParty: req.PartyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read party hosting: %w", err)
+	}
+
+	var mapping *model.PartyToParticipantResult
+	for _, r := range current.Results {
+		if r.Item == nil || r.Context == nil || r.Item.Party != req.PartyID {
+			continue
+		}
+		if mapping == nil || r.Context.Serial > mapping.Context.Serial {
+			mapping = r
+		}
+	}
+	if mapping == nil {
+		return nil, fmt.Errorf("party %s is not hosted on synchronizer %s", req.PartyID, req.SynchronizerID)
+	}
+	for _, p := range mapping.Item.Participants {
+		if p.ParticipantUID == targetUID {
+			return nil, fmt.Errorf("party %s is already hosted on participant %s", req.PartyID, targetUID)
+		}
+	}
+
+	serial := uint32(mapping.Context.Serial) + 1
+
+	if !req.SkipProposal {
+		participants := append([]model.HostingParticipant{}, mapping.Item.Participants...)
+		participants = append(participants, model.HostingParticipant{
+			ParticipantUID: targetUID,
+			Permission:     req.Permission,
+			Onboarding:     true,
+		})
+
+		authorize := &model.AuthorizeRequest{
+			Proposal: &model.TopologyTransactionProposal{
+				Operation: model.OperationAddReplace,
+				Mapping: &model.PartyToParticipantMapping{
+					Party:        req.PartyID,
+					Threshold:    mapping.Item.Threshold,
+					Participants: participants,
+				},
+				Serial: serial,
+			},
+			Store: store,
+		}
+		if _, err := c.TopologyManagerWrite.Authorize(ctx, authorize); err != nil {
+			return nil, fmt.Errorf("failed to propose hosting on %s: %w", targetUID, err)
+		}
+		if _, err := target.TopologyManagerWrite.Authorize(ctx, authorize); err != nil {
+			return nil, fmt.Errorf("failed to authorize hosting on %s: %w", targetUID, err)
+		}
+	}
+
+	args := &model.AddPartyArguments{
+		PartyID:               req.PartyID,
+		SynchronizerID:        req.SynchronizerID,
+		SourceParticipantUID:  sourceUID,
+		TopologySerial:        serial,
+		ParticipantPermission: req.Permission,
+	}
+
+	if _, err := c.PartyReplication.AddPartyAsync(ctx, args); err != nil {
+		return nil, fmt.Errorf("failed to start party replication on source: %w", err)
+	}
+	requestID, err := target.PartyReplication.AddPartyAsync(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start party replication on target: %w", err)
+	}
+
+	interval := req.PollInterval
+	if interval <= 0 {
+		interval = defaultPartyReplicationPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := target.PartyReplication.GetAddPartyStatus(ctx, requestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get party replication status: %w", err)
+		}
+		if req.OnProgress != nil {
+			req.OnProgress(status)
+		}
+		if status.Error != "" {
+			return status, fmt.Errorf("party replication %s failed: %s", requestID, status.Error)
+		}
+		if status.Completed {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *DamlBindingClient) participantUID(ctx context.Context) (string, error) {
+	status, err := c.Diagnostics.ParticipantStatus(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !status.Initialized {
+		return "", errors.New("participant is not initialized")
+	}
+	return status.UID, nil
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+// PruneToRetention prunes the participant up to the highest offset that is both
+// older than retention and safe to prune with respect to ACS commitments. It
+// returns the pruned offset, or 0 when there is nothing safe to prune yet.
+func (c *DamlBindingClient) PruneToRetention(ctx context.Context, retention time.Duration, pruneAllDivulgedContracts bool) (int64, error) {
+	cutoff := time.Now().Add(-retention)
+
+	ledgerEnd, err := c.InspectionMng.LookupOffsetByTime(ctx, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up offset at %s: %w", cutoff.Format(time.RFC3339), err)
+	}
+	if ledgerEnd == nil {
+		return 0, nil
+	}
+
+	safeOffset, err := c.CantonPruningMng.GetSafePruningOffset(ctx, &model.GetSafePruningOffsetRequest{
+		BeforeOrAt: cutoff,
+		LedgerEnd:  *ledgerEnd,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get safe pruning offset: %w", err)
+	}
+	if safeOffset == nil || *safeOffset <= 0 {
+		return 0, nil
+	}
+
+	err = c.PruningMng.Prune(ctx, &model.PruneRequest{
+		PruneUpTo:                 *safeOffset,
+		PruneAllDivulgedContracts: pruneAllDivulgedContracts,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune up to offset %d: %w", *safeOffset, err)
+	}
+
+	return *safeOffset, nil
+}
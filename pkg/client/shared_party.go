@@ -0,0 +1,262 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+)
+
+const (
+	defaultSharedPartyTimeout      = 2 * time.Minute
+	defaultSharedPartyPollInterval = time.Second
+)
+
+type SharedPartyRequest struct {
+	PartyHint      string
+	SynchronizerID string
+	// Owners hold the root keys of the namespaces that jointly control the
+	// party's decentralized namespace. Owner namespaces not yet known on the
+	// synchronizer are registered with a root certificate first.
+	Owners []topology.Signer
+	// OwnerThreshold is the number of owners needed to authorize changes.
+	OwnerThreshold int32
+	// Hosts authorize hosting the party with their own participant keys.
+	Hosts []*SharedPartyHost
+	// ConfirmationThreshold is the number of hosts that must confirm.
+	ConfirmationThreshold uint32
+	// Timeout bounds the whole setup; defaults to two minutes.
+	Timeout      time.Duration
+	PollInterval time.Duration
+}
+
+type SharedPartyHost struct {
+	Client     *DamlBindingClient
+	Permission model.ParticipantPermission
+}
+
+type SharedPartyResult struct {
+	PartyID   string
+	Namespace string
+}
+
+// CreateSharedParty creates a party in a decentralized namespace owned by
+// several keys and hosts it on several participants. Each owner signs the
+// namespace definition and the party hosting as a topology proposal through
+// this participant, each host authorizes the hosting proposal, and the call
+// returns once the party is hosted on the synchronizer.
+func (c *DamlBindingClient) CreateSharedParty(ctx context.Context, req *SharedPartyRequest) (*SharedPartyResult, error) {
+	if req.PartyHint == "" || req.SynchronizerID == "" {
+		return nil, errors.New("party hint and synchronizer ID are required")
+	}
+	if len(req.Owners) == 0 || len(req.Hosts) == 0 {
+		return nil, errors.New("at least one owner and one host are required")
+	}
+	if req.OwnerThreshold < 1 || int(req.OwnerThreshold) > len(req.Owners) {
+		return nil, fmt.Errorf("owner threshold must be between 1 and %d", len(req.Owners))
+	}
+	if req.ConfirmationThreshold < 1 || int(req.ConfirmationThreshold) > len(req.Hosts) {
+		return nil, fmt.Errorf("confirmation threshold must be between 1 and %d", len(req.Hosts))
+	}
+
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = defaultSharedPartyTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	interval := req.PollInterval
+	if interval <= 0 {
+		interval = defaultSharedPartyPollInterval
+	}
+
+	store := &model.StoreID{Value: "synchronizer:" + req.SynchronizerID}
+
+	owners := make([]string, len(req.Owners))
+	for i, owner := range req.Owners {
+		owners[i] = owner.Fingerprint()
+		if err := c.ensureRootCertificate(ctx, store, owner); err != nil {
+			return nil, err
+		}
+	}
+
+	namespace, err := topology.DecentralizedNamespace(owners)
+	if err != nil {
+		return nil, err
+	}
+	result := &SharedPartyResult{
+		PartyID:   req.PartyHint + "::" + namespace,
+		Namespace: namespace,
+	}
+
+	definition, err := topology.BuildTransaction(model.OperationAddReplace, 1, &model.DecentralizedNamespaceDefinitionMapping{
+		Namespace: namespace,
+		Threshold: req.OwnerThreshold,
+		Owners:    owners,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.proposeAsOwners(ctx, store, definition, req.Owners); err != nil {
+		return nil, fmt.Errorf("failed to propose decentralized namespace %s: %w", namespace, err)
+	}
+	err = pollUntil(ctx, interval, func() (bool, error) {
+		resp, err := c.TopologyManagerRead.ListDecentralizedNamespaceDefinition(ctx, &model.ListDecentralizedNamespaceDefinitionRequest{
+			BaseQuery:       &model.BaseQuery{Store: store},
+			FilterNamespace: namespace,
+		})
+		if err != nil {
+			return false, err
+		}
+		return len(resp.Results) > 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decentralized namespace %s did not become effective: %w", namespace, err)
+	}
+
+	participants := make([]model.HostingParticipant, len(req.Hosts))
+	for i, host := range req.Hosts {
+		uid, err := host.Client.participantUID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get host participant ID: %w", err)
+		}
+		participants[i] = model.HostingParticipant{
+			ParticipantUID: uid,
+			Permission:     host.Permission,
+		}
+	}
+
+	hosting, err := topology.BuildTransaction(model.OperationAddReplace, 1, &model.PartyToParticipantMapping{
+		Party:        result.PartyID,
+		Threshold:    req.ConfirmationThreshold,
+		Participants: participants,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.proposeAsOwners(ctx, store, hosting, req.Owners); err != nil {
+		return nil, fmt.Errorf("failed to propose hosting of %s: %w", result.PartyID, err)
+	}
+
+	// Hosts can only authorize the proposal once it has reached them through
+	// the synchronizer.
+	hash := hex.EncodeToString(hosting.TransactionHash)
+	for i, host := range req.Hosts {
+		var lastErr error
+		err := pollUntil(ctx, interval, func() (bool, error) {
+			_, lastErr = host.Client.TopologyManagerWrite.Authorize(ctx, &model.AuthorizeRequest{
+				TransactionHash: hash,
+				Store:           store,
+			})
+			return lastErr == nil, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("host %s failed to authorize hosting of %s: %w", participants[i].ParticipantUID, result.PartyID, errors.Join(err, lastErr))
+		}
+	}
+
+	err = pollUntil(ctx, interval, func() (bool, error) {
+		resp, err := c.TopologyManagerRead.ListPartyToParticipant(ctx, &model.ListPartyToParticipantRequest{
+			BaseQuery:   &model.BaseQuery{Store: store},
+			FilterParty: result.PartyID,
+		})
+		if err != nil {
+			return false, err
+		}
+		return len(resp.Results) > 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hosting of %s did not become effective: %w", result.PartyID, err)
+	}
+
+	return result, nil
+}
+
+// ensureRootCertificate registers the self-signed root namespace delegation of
+// an owner unless its namespace already exists in the store.
+func (c *DamlBindingClient) ensureRootCertificate(ctx context.Context, store *model.StoreID, owner topology.Signer) error {
+	resp, err := c.TopologyManagerRead.ListNamespaceDelegation(ctx, &model.ListNamespaceDelegationRequest{
+		BaseQuery:       &model.BaseQuery{Store: store},
+		FilterNamespace: owner.Fingerprint(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read namespace %s: %w", owner.Fingerprint(), err)
+	}
+	if len(resp.Results) > 0 {
+		return nil
+	}
+
+	tx, err := topology.BuildTransaction(model.OperationAddReplace, 1, &model.NamespaceDelegationMapping{
+		Namespace:        owner.Fingerprint(),
+		TargetKey:        owner.PublicKey(model.SigningKeyUsageNamespace),
+		IsRootDelegation: true,
+	})
+	if err != nil {
+		return err
+	}
+	signed, err := topology.SignTransaction(tx, owner)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.TopologyManagerWrite.AddTransactions(ctx, &model.AddTransactionsRequest{
+		Transactions: []*model.SignedTopologyTransaction{signed},
+		Store:        store,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add root certificate of %s: %w", owner.Fingerprint(), err)
+	}
+
+	return nil
+}
+
+// proposeAsOwners submits the transaction once per owner, each time as a
+// proposal carrying only that owner's signature. The synchronizer merges the
+// signatures and turns the proposal into a transaction once it is fully
+// authorized.
+func (c *DamlBindingClient) proposeAsOwners(ctx context.Context, store *model.StoreID, tx *model.GeneratedTransaction, owners []topology.Signer) error {
+	for _, owner := range owners {
+		signed, err := topology.SignTransaction(tx, owner)
+		if err != nil {
+			return err
+		}
+		signed.Proposal = true
+
+		_, err = c.TopologyManagerWrite.AddTransactions(ctx, &model.AddTransactionsRequest{
+			Transactions: []*model.SignedTopologyTransaction{signed},
+			Store:        store,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add proposal signed by %s: %w", owner.Fingerprint(), err)
+		}
+	}
+	return nil
+}
+
+// pollUntil calls check every interval until it reports done, fails or the
+// context ends.
+func pollUntil(ctx context.Context, interval time.Duration, check func() (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/service/mediator"
+	"github.com/noders-team/go-daml/pkg/service/sequencer"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+)
+
+// SequencerAdminClient talks to the admin API of a sequencer node. Only
+// Address, TLS, Auth and GRPCDialOptions of the config are used.
+type SequencerAdminClient struct {
+	conn                 *grpc.ClientConn
+	Administration       sequencer.Administration
+	Pruning              sequencer.Pruning
+	TrafficInspection    sequencer.TrafficInspection
+	BftAdministration    sequencer.BftAdministration
+	Status               sequencer.Status
+	TopologyManagerRead  topology.TopologyManagerRead
+	TopologyManagerWrite topology.TopologyManagerWrite
+}
+
+// NewSequencerAdminClient creates the client without dialing; the connection is
+// established on the first call.
+func NewSequencerAdminClient(config *Config) (*SequencerAdminClient, error) {
+	conn, err := dialNodeAdmin(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to sequencer admin endpoint: %w", err)
+	}
+
+	return &SequencerAdminClient{
+		conn:                 conn,
+		Administration:       sequencer.NewAdministrationClient(conn),
+		Pruning:              sequencer.NewPruningClient(conn),
+		TrafficInspection:    sequencer.NewTrafficInspectionClient(conn),
+		BftAdministration:    sequencer.NewBftAdministrationClient(conn),
+		Status:               sequencer.NewStatusClient(conn),
+		TopologyManagerRead:  topology.NewTopologyManagerReadClient(conn),
+		TopologyManagerWrite: topology.NewTopologyManagerWriteClient(conn),
+	}, nil
+}
+
+func (c *SequencerAdminClient) Close() error {
+	return c.conn.Close()
+}
+
+// MediatorAdminClient talks to the admin API of a mediator node. Only
+// Address, TLS, Auth and GRPCDialOptions of the config are used.
+type MediatorAdminClient struct {
+	conn                 *grpc.ClientConn
+	Pruning              mediator.Pruning
+	Status               mediator.Status
+	TopologyManagerRead  topology.TopologyManagerRead
+	TopologyManagerWrite topology.TopologyManagerWrite
+}
+
+// NewMediatorAdminClient creates the client without dialing; the connection is
+// established on the first call.
+func NewMediatorAdminClient(config *Config) (*MediatorAdminClient, error) {
+	conn, err := dialNodeAdmin(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mediator admin endpoint: %w", err)
+	}
+
+	return &MediatorAdminClient{
+		conn:                 conn,
+		Pruning:              mediator.NewPruningClient(conn),
+		Status:               mediator.NewStatusClient(conn),
+		TopologyManagerRead:  topology.NewTopologyManagerReadClient(conn),
+		TopologyManagerWrite: topology.NewTopologyManagerWriteClient(conn),
+	}, nil
+}
+
+func (c *MediatorAdminClient) Close() error {
+	return c.conn.Close()
+}
+
+func dialNodeAdmin(config *Config) (*grpc.ClientConn, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+
+	opts, err := NewClient(config).buildDialOptions(config.TLS, config.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	return grpc.NewClient(config.Address, opts...)
+}
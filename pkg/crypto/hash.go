@@ -4,13 +4,18 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 const (
-	CantonHashPurposePublicKeyFingerprint  = 12
-	CantonHashPurposePreparedTransaction   = 48
-	CantonHashPurposeMultiTopologyTxHashes = 55
+	CantonHashPurposeTopologyTransaction    = 11
+	CantonHashPurposePublicKeyFingerprint   = 12
+	CantonHashPurposeDecentralizedNamespace = 37
+	CantonHashPurposePreparedTransaction    = 48
+	CantonHashPurposeMultiTopologyTxHashes  = 55
 )
 
 func ComputeSHA256CantonHash(purpose int, data []byte) ([]byte, error) {
@@ -35,12 +40,22 @@ func ComputeSHA256CantonHash(purpose int, data []byte) ([]byte, error) {
 	return fullHash, nil
 }
 
+// ComputeMultiHashForTopology computes the combined hash that a
+// multi-transaction signature signs: the count of the hashes followed by the
+// length prefixed hashes, sorted by their hex form.
 func ComputeMultiHashForTopology(hashes [][]byte) ([]byte, error) {
-	h := sha256.New()
-	for _, hash := range hashes {
-		h.Write(hash)
+	sorted := slices.Clone(hashes)
+	slices.SortFunc(sorted, func(a, b []byte) int {
+		return strings.Compare(hex.EncodeToString(a), hex.EncodeToString(b))
+	})
+
+	data := binary.BigEndian.AppendUint32(nil, uint32(len(sorted)))
+	for _, hash := range sorted {
+		data = binary.BigEndian.AppendUint32(data, uint32(len(hash)))
+		data = append(data, hash...)
 	}
-	return h.Sum(nil), nil
+
+	return ComputeSHA256CantonHash(CantonHashPurposeMultiTopologyTxHashes, data)
 }
 
 func HashPreparedTransaction(preparedTransactionBase64 string) (string, error) {
@@ -0,0 +1,41 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeMultiHashForTopology(t *testing.T) {
+	a := append([]byte{0x12, 0x20}, bytes.Repeat([]byte{0xaa}, 32)...)
+	b := append([]byte{0x12, 0x20}, bytes.Repeat([]byte{0x11}, 32)...)
+
+	tests := []struct {
+		name     string
+		hashes   [][]byte
+		expected string
+	}{
+		{name: "empty", hashes: nil, expected: "12203e9eb039f682d656e0a1ebb1860cc4e2479822bf12253239b2f46ae81ed7bc16"},
+		{name: "single", hashes: [][]byte{a}, expected: "122074aa21b67a32365db4f627ec5d14588e3d772b9847086a97f20959087520beee"},
+		{name: "sorted", hashes: [][]byte{b, a}, expected: "122009e5ea622d054d470173192434a2efc0ef41118a9081dce33c82fa48933b9604"},
+		{name: "unsorted", hashes: [][]byte{a, b}, expected: "122009e5ea622d054d470173192434a2efc0ef41118a9081dce33c82fa48933b9604"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := ComputeMultiHashForTopology(tt.hashes)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, hex.EncodeToString(hash))
+		})
+	}
+}
+
+func TestComputeMultiHashForTopologyKeepsInputOrder(t *testing.T) {
+	hashes := [][]byte{{0x02}, {0x01}}
+
+	_, err := ComputeMultiHashForTopology(hashes)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x02}, {0x01}}, hashes)
+}
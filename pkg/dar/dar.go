@@ -0,0 +1,73 @@
+package dar
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const manifestPath = "META-INF/MANIFEST.MF"
+
+// Info describes a DAR as read from its manifest.
+type Info struct {
+	Name          string
+	SdkVersion    string
+	MainDalf      string
+	MainPackageID string
+	// Dependencies holds the package IDs of all DALFs in the DAR other than
+	// the main one.
+	Dependencies []string
+}
+
+// Inspect reads the manifest of a DAR without uploading it anywhere.
+func Inspect(data []byte) (*Info, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dar: %w", err)
+	}
+
+	f, err := zr.Open(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dar manifest: %w", err)
+	}
+	defer f.Close()
+
+	manifest, err := ParseManifest(f)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{
+		Name:       manifest.Name,
+		SdkVersion: manifest.SdkVersion,
+		MainDalf:   manifest.MainDalf,
+	}
+	info.MainPackageID = PackageIDFromDalf(info.MainDalf)
+	if info.MainPackageID == "" {
+		return nil, fmt.Errorf("no package ID in main dalf %q", info.MainDalf)
+	}
+
+	for _, dalf := range manifest.Dalfs {
+		if dalf == info.MainDalf {
+			continue
+		}
+		if id := PackageIDFromDalf(dalf); id != "" {
+			info.Dependencies = append(info.Dependencies, id)
+		}
+	}
+
+	return info, nil
+}
+
+// PackageIDFromDalf returns the package ID encoded in a DALF file name, which
+// ends with "-<package ID>.dalf".
+func PackageIDFromDalf(dalf string) string {
+	name := strings.TrimSuffix(path.Base(dalf), ".dalf")
+	i := strings.LastIndex(name, "-")
+	if i == -1 || i == len(name)-1 {
+		return ""
+	}
+	return name[i+1:]
+}
@@ -0,0 +1,49 @@
+package dar
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect(t *testing.T) {
+	data, err := os.ReadFile("../../test-data/all-kinds-of-1.0.0.dar")
+	require.NoError(t, err)
+
+	info, err := Inspect(data)
+	require.NoError(t, err)
+	require.Equal(t, "all-kinds-of-1.0.0", info.Name)
+	require.Equal(t, "ddf0d6396a862eaa7f8d647e39d090a6b04c4a3fd6736aa1730ebc9fca6be664", info.MainPackageID)
+	require.NotEmpty(t, info.Dependencies)
+	require.NotContains(t, info.Dependencies, info.MainPackageID)
+	require.Contains(t, info.Dependencies, "54f85ebfc7dfae18f7d70370015dcc6c6792f60135ab369c44ae52c6fc17c274")
+}
+
+func TestInspectRejectsNonDar(t *testing.T) {
+	_, err := Inspect([]byte("not a zip"))
+	require.Error(t, err)
+}
+
+func TestParseManifest(t *testing.T) {
+	content := "Manifest-Version: 1.0\r\n" +
+		"Created-By: damlc\r\n" +
+		"Name: app-1.0.0\r\n" +
+		"Main-Dalf: app-1.0.0-1234/app-1.0.0-12345678\r\n" +
+		" 90.dalf\r\n" +
+		"Dalfs: app-1.0.0-1234/app-1.0.0-1234567890.dalf, daml-prim-abc.dalf,da\r\n" +
+		" ml-stdlib-def.dalf\r\n" +
+		"Format: daml-lf\r\n"
+
+	manifest, err := ParseManifest(strings.NewReader(content))
+	require.NoError(t, err)
+	require.Equal(t, "1.0", manifest.Version)
+	require.Equal(t, "damlc", manifest.CreatedBy)
+	require.Equal(t, "app-1.0.0-1234/app-1.0.0-1234567890.dalf", manifest.MainDalf)
+	require.Equal(t, []string{"app-1.0.0-1234/app-1.0.0-1234567890.dalf", "daml-prim-abc.dalf", "daml-stdlib-def.dalf"}, manifest.Dalfs)
+	require.Equal(t, "daml-lf", manifest.Format)
+
+	_, err = ParseManifest(strings.NewReader("Manifest-Version: 1.0\n"))
+	require.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package dar
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// Manifest holds the attributes of a DAR's META-INF/MANIFEST.MF.
+type Manifest struct {
+	Version    string
+	CreatedBy  string
+	Name       string
+	SdkVersion string
+	MainDalf   string
+	Dalfs      []string
+	Format     string
+	Encryption string
+}
+
+// ParseManifest parses a DAR manifest. It fails if the manifest names no main
+// DALF.
+func ParseManifest(r io.Reader) (*Manifest, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Manifest lines are wrapped at 72 bytes; continuation lines start with a
+	// single space.
+	content := strings.ReplaceAll(string(b), "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\n ", "")
+
+	manifest := &Manifest{}
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Manifest-Version":
+			manifest.Version = value
+		case "Created-By":
+			manifest.CreatedBy = value
+		case "Name":
+			manifest.Name = value
+		case "Sdk-Version":
+			manifest.SdkVersion = value
+		case "Main-Dalf":
+			manifest.MainDalf = value
+		case "Dalfs":
+			for _, dalf := range strings.Split(value, ",") {
+				if dalf = strings.TrimSpace(dalf); dalf != "" {
+					manifest.Dalfs = append(manifest.Dalfs, dalf)
+				}
+			}
+		case "Format":
+			manifest.Format = value
+		case "Encryption":
+			manifest.Encryption = value
+		}
+	}
+
+	if manifest.MainDalf == "" {
+		return nil, errors.New("main-dalf not found in manifest")
+	}
+
+	return manifest, nil
+}
@@ -8,6 +8,22 @@ type User struct {
 	IsDeactivated      bool
 	Metadata           map[string]string
 	IdentityProviderID string
+	// ResourceVersion is set by the participant on reads. Passing it back
+	// unchanged on update makes the update fail if the user was modified
+	// concurrently; leave it empty to skip the check.
+	ResourceVersion string
+}
+
+// Field mask paths accepted by UpdateUser.
+const (
+	UserUpdatePathPrimaryParty  = "primary_party"
+	UserUpdatePathIsDeactivated = "is_deactivated"
+	UserUpdatePathAnnotations   = "metadata.annotations"
+)
+
+type ListUsersResponse struct {
+	Users         []*User
+	NextPageToken string
 }
 
 type Right struct {
@@ -38,6 +54,9 @@ type IdentityProviderAdmin struct{}
 
 func (IdentityProviderAdmin) isRightType() {}
 
+// Field mask path accepted by UpdatePartyDetails.
+const PartyUpdatePathAnnotations = "local_metadata.annotations"
+
 type PartyDetails struct {
 	Party              string
 	IsLocal            bool
@@ -87,6 +106,14 @@ type IdentityProviderConfig struct {
 	Audience           string
 }
 
+// Field mask paths accepted by UpdateIdentityProviderConfig.
+const (
+	IdentityProviderUpdatePathIsDeactivated = "is_deactivated"
+	IdentityProviderUpdatePathIssuer        = "issuer"
+	IdentityProviderUpdatePathJwksURL       = "jwks_url"
+	IdentityProviderUpdatePathAudience      = "audience"
+)
+
 type UpdateMask struct {
 	Paths []string
 }
@@ -136,3 +163,603 @@ const (
 	UpdateVettedPackagesForceFlagAllowVetIncompatibleUpgrades UpdateVettedPackagesForceFlag = 2
 	UpdateVettedPackagesForceFlagAllowUnvettedDependencies    UpdateVettedPackagesForceFlag = 3
 )
+
+type DarDescription struct {
+	MainPackageID string
+	Name          string
+	Version       string
+	Description   string
+}
+
+type PackageDescription struct {
+	PackageID  string
+	Name       string
+	Version    string
+	UploadedAt *time.Time
+	Size       uint32
+}
+
+type Dar struct {
+	Payload     []byte
+	Description *DarDescription
+}
+
+type DarContents struct {
+	Description *DarDescription
+	Packages    []*PackageDescription
+}
+
+type ListDarsRequest struct {
+	Limit      int32
+	FilterName string
+}
+
+type VetDarRequest struct {
+	MainPackageID  string
+	Synchronize    bool
+	SynchronizerID string
+}
+
+type UnvetDarRequest struct {
+	MainPackageID  string
+	SynchronizerID string
+}
+
+type SequencerConnectionValidation int32
+
+const (
+	SequencerConnectionValidationUnspecified     SequencerConnectionValidation = 0
+	SequencerConnectionValidationDisabled        SequencerConnectionValidation = 1
+	SequencerConnectionValidationActive          SequencerConnectionValidation = 2
+	SequencerConnectionValidationAll             SequencerConnectionValidation = 3
+	SequencerConnectionValidationThresholdActive SequencerConnectionValidation = 4
+)
+
+type SynchronizerConnectionMode int32
+
+const (
+	SynchronizerConnectionModeUnspecified SynchronizerConnectionMode = 0
+	SynchronizerConnectionModeNone        SynchronizerConnectionMode = 1
+	SynchronizerConnectionModeHandshake   SynchronizerConnectionMode = 2
+)
+
+type SequencerConnection struct {
+	Alias                   string
+	SequencerID             string
+	Endpoints               []string
+	TransportSecurity       bool
+	CustomTrustCertificates []byte
+}
+
+type SubmissionRequestAmplification struct {
+	Factor   uint32
+	Patience *time.Duration
+}
+
+type SequencerConnections struct {
+	Connections                    []*SequencerConnection
+	SequencerTrustThreshold        uint32
+	SequencerLivenessMargin        uint32
+	SubmissionRequestAmplification *SubmissionRequestAmplification
+}
+
+type SynchronizerConnectionConfig struct {
+	SynchronizerAlias                 string
+	SequencerConnections              *SequencerConnections
+	ManualConnect                     bool
+	PhysicalSynchronizerID            string
+	Priority                          int32
+	InitialRetryDelay                 *time.Duration
+	MaxRetryDelay                     *time.Duration
+	InitializeFromTrustedSynchronizer bool
+}
+
+type RegisterSynchronizerRequest struct {
+	Config                        *SynchronizerConnectionConfig
+	ConnectionMode                SynchronizerConnectionMode
+	SequencerConnectionValidation SequencerConnectionValidation
+}
+
+type ConnectSynchronizerRequest struct {
+	Config                        *SynchronizerConnectionConfig
+	SequencerConnectionValidation SequencerConnectionValidation
+}
+
+type ModifySynchronizerRequest struct {
+	PhysicalSynchronizerID        string
+	NewConfig                     *SynchronizerConnectionConfig
+	SequencerConnectionValidation SequencerConnectionValidation
+}
+
+type ConnectedSynchronizerStatus struct {
+	SynchronizerAlias      string
+	SynchronizerID         string
+	PhysicalSynchronizerID string
+	Healthy                bool
+}
+
+type RegisteredSynchronizer struct {
+	Config                 *SynchronizerConnectionConfig
+	Connected              bool
+	PhysicalSynchronizerID string
+}
+
+type GetSynchronizerIDResponse struct {
+	SynchronizerID         string
+	PhysicalSynchronizerID string
+}
+
+type ContractImportMode int32
+
+const (
+	ContractImportModeUnspecified ContractImportMode = 0
+	ContractImportModeAccept      ContractImportMode = 1
+	ContractImportModeValidation  ContractImportMode = 2
+)
+
+type ExportAcsRequest struct {
+	PartyIDs       []string
+	SynchronizerID string
+	LedgerOffset   int64
+	// ContractSynchronizerRenames maps a source synchronizer ID to the
+	// synchronizer ID the exported contracts should be assigned to.
+	ContractSynchronizerRenames map[string]string
+	ExcludedStakeholderIDs      []string
+}
+
+type ImportAcsRequest struct {
+	SynchronizerID         string
+	WorkflowIDPrefix       string
+	ContractImportMode     ContractImportMode
+	ExcludedStakeholderIDs []string
+	// ChunkSize bounds the size of each streamed message; defaults to 1 MiB.
+	ChunkSize                       int
+	RepresentativePackageIDOverride *RepresentativePackageIDOverride
+}
+
+type RepresentativePackageIDOverride struct {
+	ContractOverride    map[string]string
+	PackageIDOverride   map[string]string
+	PackageNameOverride map[string]string
+}
+
+// Repair requests below change participant state irreversibly and are rejected
+// unless Confirm is set.
+
+type PurgeContractsRequest struct {
+	SynchronizerAlias   string
+	ContractIDs         []string
+	IgnoreAlreadyPurged bool
+	Confirm             bool
+}
+
+type MigrateSynchronizerRequest struct {
+	SourceSynchronizerAlias string
+	TargetConfig            *SynchronizerConnectionConfig
+	Force                   bool
+	Confirm                 bool
+}
+
+type ChangeAssignationContract struct {
+	ContractID                  string
+	ReassignmentCounterOverride *int64
+}
+
+type ChangeAssignationRequest struct {
+	SourceSynchronizerAlias string
+	TargetSynchronizerAlias string
+	SkipInactive            bool
+	Contracts               []*ChangeAssignationContract
+	Confirm                 bool
+}
+
+type IgnoreEventsRequest struct {
+	PhysicalSynchronizerID string
+	FromInclusive          int64
+	ToInclusive            int64
+	Force                  bool
+	Confirm                bool
+}
+
+type UnignoreEventsRequest struct {
+	PhysicalSynchronizerID string
+	FromInclusive          int64
+	ToInclusive            int64
+	Force                  bool
+	Confirm                bool
+}
+
+type RollbackUnassignmentRequest struct {
+	ReassignmentID       string
+	SourceSynchronizerID string
+	TargetSynchronizerID string
+	Confirm              bool
+}
+
+type PurgeDeactivatedSynchronizerRequest struct {
+	SynchronizerAlias string
+	Confirm           bool
+}
+
+type SafeToPruneCommitmentState int32
+
+const (
+	SafeToPruneCommitmentStateUnspecified   SafeToPruneCommitmentState = 0
+	SafeToPruneCommitmentStateMatch         SafeToPruneCommitmentState = 1
+	SafeToPruneCommitmentStateMatchMismatch SafeToPruneCommitmentState = 2
+	SafeToPruneCommitmentStateAll           SafeToPruneCommitmentState = 3
+)
+
+type PruningSchedule struct {
+	Cron        string
+	MaxDuration time.Duration
+	Retention   time.Duration
+}
+
+type ParticipantPruningSchedule struct {
+	Schedule            *PruningSchedule
+	PruneInternallyOnly bool
+}
+
+type GetSafePruningOffsetRequest struct {
+	BeforeOrAt                          time.Time
+	LedgerEnd                           int64
+	CounterParticipantsCommitmentsState SafeToPruneCommitmentState
+}
+
+type WaitCommitmentsSetup struct {
+	CounterParticipantUID string
+	SynchronizerIDs       []string
+}
+
+type NoWaitCommitmentsConfig struct {
+	IgnoredParticipants    []*WaitCommitmentsSetup
+	NotIgnoredParticipants []*WaitCommitmentsSetup
+}
+
+type SentCommitmentState int32
+
+const (
+	SentCommitmentStateUnspecified SentCommitmentState = 0
+	SentCommitmentStateMatch       SentCommitmentState = 1
+	SentCommitmentStateMismatch    SentCommitmentState = 2
+	SentCommitmentStateNotCompared SentCommitmentState = 3
+)
+
+type ReceivedCommitmentState int32
+
+const (
+	ReceivedCommitmentStateUnspecified ReceivedCommitmentState = 0
+	ReceivedCommitmentStateMatch       ReceivedCommitmentState = 1
+	ReceivedCommitmentStateMismatch    ReceivedCommitmentState = 2
+	ReceivedCommitmentStateBuffered    ReceivedCommitmentState = 3
+	ReceivedCommitmentStateOutstanding ReceivedCommitmentState = 4
+)
+
+type OpenCommitmentRequest struct {
+	Commitment                       []byte
+	PhysicalSynchronizerID           string
+	ComputedForCounterParticipantUID string
+	PeriodEndTick                    time.Time
+}
+
+type CommitmentContractMeta struct {
+	ContractID          string
+	ReassignmentCounter int64
+}
+
+type InspectCommitmentContractsRequest struct {
+	ContractIDs            []string
+	ExpectedSynchronizerID string
+	Timestamp              time.Time
+	DownloadPayload        bool
+}
+
+type ContractStateKind int32
+
+const (
+	ContractStateKindUnknown ContractStateKind = iota
+	ContractStateKindCreated
+	ContractStateKindArchived
+	ContractStateKindUnassigned
+	ContractStateKindAssigned
+)
+
+type ContractSynchronizerState struct {
+	SynchronizerID string
+	State          ContractStateKind
+	// ReassignmentCounter, TargetSynchronizerID and ReassignmentID are only
+	// populated for unassigned and assigned states.
+	ReassignmentCounter  int64
+	TargetSynchronizerID string
+	ReassignmentID       string
+}
+
+type CommitmentContract struct {
+	ContractID                   string
+	ActiveOnExpectedSynchronizer bool
+	Contract                     []byte
+	States                       []*ContractSynchronizerState
+}
+
+type SynchronizerTimeRange struct {
+	SynchronizerID string
+	FromExclusive  *time.Time
+	ToInclusive    *time.Time
+}
+
+type CommitmentInterval struct {
+	StartTickExclusive *time.Time
+	EndTickInclusive   *time.Time
+}
+
+type LookupSentAcsCommitmentsRequest struct {
+	TimeRanges            []*SynchronizerTimeRange
+	CounterParticipantIDs []string
+	States                []SentCommitmentState
+	Verbose               bool
+}
+
+type LookupReceivedAcsCommitmentsRequest struct {
+	TimeRanges            []*SynchronizerTimeRange
+	CounterParticipantIDs []string
+	States                []ReceivedCommitmentState
+	Verbose               bool
+}
+
+type SentAcsCommitment struct {
+	SynchronizerID            string
+	Interval                  *CommitmentInterval
+	DestCounterParticipantUID string
+	OwnCommitment             []byte
+	ReceivedCommitment        []byte
+	State                     SentCommitmentState
+}
+
+type ReceivedAcsCommitment struct {
+	SynchronizerID              string
+	Interval                    *CommitmentInterval
+	OriginCounterParticipantUID string
+	ReceivedCommitment          []byte
+	OwnCommitment               []byte
+	State                       ReceivedCommitmentState
+}
+
+type SlowCounterParticipantConfig struct {
+	SynchronizerIDs              []string
+	DistinguishedParticipantUIDs []string
+	ThresholdDistinguished       uint64
+	ThresholdDefault             uint64
+	ParticipantUIDsMetrics       []string
+}
+
+type GetIntervalsBehindRequest struct {
+	CounterParticipantIDs []string
+	SynchronizerIDs       []string
+	Threshold             *uint64
+}
+
+type CounterParticipantInfo struct {
+	CounterParticipantUID   string
+	SynchronizerID          string
+	IntervalsBehind         uint64
+	BehindSince             *time.Duration
+	AsOfSequencingTimestamp *time.Time
+}
+
+type InFlightCount struct {
+	PendingSubmissions  uint32
+	PendingTransactions uint32
+}
+
+type ComponentHealth int32
+
+const (
+	ComponentHealthUnspecified ComponentHealth = iota
+	ComponentHealthOk
+	ComponentHealthDegraded
+	ComponentHealthFailed
+	ComponentHealthFatal
+)
+
+type ComponentStatus struct {
+	Name        string
+	Health      ComponentHealth
+	Description string
+}
+
+type WaitingForExternalInput int32
+
+const (
+	WaitingForExternalInputUnspecified    WaitingForExternalInput = 0
+	WaitingForExternalInputID             WaitingForExternalInput = 1
+	WaitingForExternalInputNodeTopology   WaitingForExternalInput = 2
+	WaitingForExternalInputInitialization WaitingForExternalInput = 3
+)
+
+type SynchronizerHealth int32
+
+const (
+	SynchronizerHealthUnspecified SynchronizerHealth = 0
+	SynchronizerHealthHealthy     SynchronizerHealth = 1
+	SynchronizerHealthUnhealthy   SynchronizerHealth = 2
+)
+
+type ParticipantSynchronizerHealth struct {
+	PhysicalSynchronizerID string
+	Health                 SynchronizerHealth
+}
+
+type ParticipantStatus struct {
+	// Initialized is false while the node waits for external input; in that
+	// case only Active and WaitingForExternalInput are populated.
+	Initialized               bool
+	WaitingForExternalInput   WaitingForExternalInput
+	UID                       string
+	Uptime                    time.Duration
+	Ports                     map[string]int32
+	Active                    bool
+	Version                   string
+	Components                []*ComponentStatus
+	ConnectedSynchronizers    []*ParticipantSynchronizerHealth
+	SupportedProtocolVersions []int32
+}
+
+type PingRequest struct {
+	TargetParties  []string
+	Validators     []string
+	Timeout        time.Duration
+	Levels         uint32
+	SynchronizerID string
+	WorkflowID     string
+	ID             string
+}
+
+type PingResult struct {
+	Success   bool
+	RoundTrip time.Duration
+	Responder string
+	Reason    string
+}
+
+type KeyPurpose int32
+
+const (
+	KeyPurposeUnspecified KeyPurpose = 0
+	KeyPurposeSigning     KeyPurpose = 1
+	KeyPurposeEncryption  KeyPurpose = 2
+)
+
+type EncryptionKeySpec int32
+
+const (
+	EncryptionKeySpecUnspecified EncryptionKeySpec = 0
+	EncryptionKeySpecECP256      EncryptionKeySpec = 1
+	EncryptionKeySpecRSA2048     EncryptionKeySpec = 2
+)
+
+type CryptoKeyFormat int32
+
+const (
+	CryptoKeyFormatUnspecified             CryptoKeyFormat = 0
+	CryptoKeyFormatDER                     CryptoKeyFormat = 2
+	CryptoKeyFormatRaw                     CryptoKeyFormat = 3
+	CryptoKeyFormatDERX509SubjectPublicKey CryptoKeyFormat = 4
+	CryptoKeyFormatDERPKCS8PrivateKeyInfo  CryptoKeyFormat = 5
+	CryptoKeyFormatSymbolic                CryptoKeyFormat = 10000
+)
+
+// KeyDescriptor describes a public key held in a Canton node's vault.
+// SigningKeySpec and Usage are only set for signing keys, EncryptionKeySpec
+// only for encryption keys.
+type KeyDescriptor struct {
+	Fingerprint       string
+	Name              string
+	Purpose           KeyPurpose
+	Format            CryptoKeyFormat
+	PublicKey         []byte
+	SigningKeySpec    SigningKeySpec
+	EncryptionKeySpec EncryptionKeySpec
+	Usage             []SigningKeyUsage
+}
+
+type PrivateKeyDescriptor struct {
+	Key          *KeyDescriptor
+	WrapperKeyID string
+	KmsKeyID     string
+}
+
+type ListKeysFilter struct {
+	Fingerprint string
+	Name        string
+	Purposes    []KeyPurpose
+	Usages      []SigningKeyUsage
+}
+
+type GenerateSigningKeyRequest struct {
+	Name    string
+	KeySpec SigningKeySpec
+	Usage   []SigningKeyUsage
+}
+
+type GenerateEncryptionKeyRequest struct {
+	Name    string
+	KeySpec EncryptionKeySpec
+}
+
+type RegisterKmsSigningKeyRequest struct {
+	KmsKeyID string
+	Name     string
+	Usage    []SigningKeyUsage
+}
+
+type RegisterKmsEncryptionKeyRequest struct {
+	KmsKeyID string
+	Name     string
+}
+
+type ExportKeyPairRequest struct {
+	Fingerprint     string
+	ProtocolVersion int32
+	// Password encrypts the exported key pair when set.
+	Password string
+}
+
+type ImportKeyPairRequest struct {
+	KeyPair  []byte
+	Name     string
+	Password string
+}
+
+type AddPartyArguments struct {
+	PartyID               string
+	SynchronizerID        string
+	SourceParticipantUID  string
+	TopologySerial        uint32
+	ParticipantPermission ParticipantPermission
+}
+
+type PartyReplicationStatus struct {
+	RequestID              string
+	PartyID                string
+	SynchronizerID         string
+	SourceParticipantUID   string
+	TargetParticipantUID   string
+	TopologySerial         uint32
+	SequencerUID           string
+	OnboardingAt           *time.Time
+	OnboardingFlagCleared  bool
+	ProcessedContractCount uint64
+	FullyProcessedAcs      bool
+	Indexing               bool
+	Completed              bool
+	Error                  string
+}
+
+type ExportPartyAcsRequest struct {
+	PartyID                  string
+	SynchronizerID           string
+	TargetParticipantUID     string
+	BeginOffsetExclusive     int64
+	WaitForActivationTimeout *time.Duration
+}
+
+type ClearPartyOnboardingFlagRequest struct {
+	PartyID                  string
+	SynchronizerID           string
+	BeginOffsetExclusive     int64
+	WaitForActivationTimeout *time.Duration
+}
+
+type ClearPartyOnboardingFlagResult struct {
+	Onboarded bool
+	// EarliestRetry is set while the flag cannot be cleared yet.
+	EarliestRetry *time.Time
+}
+
+// ResourceLimits throttles a participant. Nil limits are unbounded.
+type ResourceLimits struct {
+	MaxInflightValidationRequests *uint32
+	MaxSubmissionRate             *uint32
+	MaxSubmissionBurstFactor      float64
+}
@@ -0,0 +1,139 @@
+package model
+
+import "time"
+
+// NodeStatus is the status common to all Canton nodes.
+type NodeStatus struct {
+	// Initialized is false while the node waits for external input; in that
+	// case only Active and WaitingForExternalInput are populated.
+	Initialized             bool
+	WaitingForExternalInput WaitingForExternalInput
+	UID                     string
+	Uptime                  time.Duration
+	Ports                   map[string]int32
+	Active                  bool
+	Version                 string
+	Components              []*ComponentStatus
+}
+
+type SequencerStatus struct {
+	NodeStatus
+	ConnectedParticipants  []string
+	ConnectedMediators     []string
+	SequencerActive        bool
+	SequencerDetails       string
+	PhysicalSynchronizerID string
+	AcceptsAdminChanges    bool
+	ProtocolVersion        int32
+}
+
+type MediatorStatus struct {
+	NodeStatus
+	PhysicalSynchronizerID string
+	ProtocolVersion        int32
+}
+
+type SequencerPruningStatus struct {
+	Now                    time.Time
+	EarliestEventTimestamp time.Time
+	Members                []*SequencerMemberStatus
+}
+
+type SequencerMemberStatus struct {
+	Member           string
+	RegisteredAt     time.Time
+	LastAcknowledged *time.Time
+	Enabled          bool
+}
+
+type TrafficRelativeTimestamp int32
+
+const (
+	TrafficRelativeTimestampLatestSafe          TrafficRelativeTimestamp = 0
+	TrafficRelativeTimestampLastUpdatePerMember TrafficRelativeTimestamp = 1
+	TrafficRelativeTimestampLatestApproximate   TrafficRelativeTimestamp = 2
+)
+
+type TrafficControlStateRequest struct {
+	Members []string
+	// ExactTimestamp takes precedence over RelativeTimestamp when set.
+	ExactTimestamp    *time.Time
+	RelativeTimestamp TrafficRelativeTimestamp
+}
+
+type MemberTrafficState struct {
+	ExtraTrafficPurchased int64
+	ExtraTrafficConsumed  int64
+	BaseTrafficRemainder  int64
+	LastConsumedCost      uint64
+	Timestamp             time.Time
+	Serial                *uint32
+}
+
+type SetTrafficPurchasedRequest struct {
+	Member string
+	// Serial makes the top-up idempotent and must increase with every update.
+	Serial                uint32
+	TotalTrafficPurchased int64
+}
+
+type TrafficSummary struct {
+	SequencingTime   time.Time
+	TotalTrafficCost int64
+	Envelopes        []*EnvelopeTrafficSummary
+}
+
+type EnvelopeTrafficSummary struct {
+	TrafficCost int64
+	ViewHashes  [][]byte
+}
+
+type PeerEndpoint struct {
+	Address string
+	Port    uint32
+	// TLS is nil for plain text endpoints.
+	TLS *PeerEndpointTLS
+}
+
+type PeerEndpointTLS struct {
+	CustomServerTrustCertificate []byte
+	ClientCertificateChain       []byte
+	ClientPrivateKeyFile         string
+}
+
+type PeerEndpointID struct {
+	Address string
+	Port    uint32
+	TLS     bool
+}
+
+type PeerEndpointHealth int32
+
+const (
+	PeerEndpointHealthUnspecified     PeerEndpointHealth = 0
+	PeerEndpointHealthUnknownEndpoint PeerEndpointHealth = 1
+	PeerEndpointHealthDisconnected    PeerEndpointHealth = 2
+	PeerEndpointHealthUnauthenticated PeerEndpointHealth = 3
+	PeerEndpointHealthAuthenticated   PeerEndpointHealth = 4
+)
+
+type PeerConnectionStatus struct {
+	// EndpointID is nil for incoming connections from peers that are not
+	// configured as endpoints on this node.
+	EndpointID  *PeerEndpointID
+	Outgoing    bool
+	Health      PeerEndpointHealth
+	Description string
+	SequencerID string
+}
+
+type BftWriteReadiness struct {
+	Ready                   bool
+	AuthenticatedPeersCount int32
+	RequiredQuorum          int32
+}
+
+type BftOrderingTopology struct {
+	CurrentEpoch int64
+	SequencerIDs []string
+}
@@ -56,6 +56,118 @@ type ListPartyToParticipantResponse struct {
 	Results []*PartyToParticipantResult
 }
 
+type ListDecentralizedNamespaceDefinitionRequest struct {
+	BaseQuery       *BaseQuery
+	FilterNamespace string
+}
+
+type ListDecentralizedNamespaceDefinitionResponse struct {
+	Results []*DecentralizedNamespaceDefinitionResult
+}
+
+type ListOwnerToKeyMappingRequest struct {
+	BaseQuery          *BaseQuery
+	FilterKeyOwnerType string
+	FilterKeyOwnerUID  string
+}
+
+type ListOwnerToKeyMappingResponse struct {
+	Results []*OwnerToKeyMappingResult
+}
+
+type ListSynchronizerTrustCertificateRequest struct {
+	BaseQuery *BaseQuery
+	FilterUID string
+}
+
+type ListSynchronizerTrustCertificateResponse struct {
+	Results []*SynchronizerTrustCertificateResult
+}
+
+type ListParticipantSynchronizerPermissionRequest struct {
+	BaseQuery *BaseQuery
+	FilterUID string
+}
+
+type ListParticipantSynchronizerPermissionResponse struct {
+	Results []*ParticipantSynchronizerPermissionResult
+}
+
+type ListPartyHostingLimitsRequest struct {
+	BaseQuery *BaseQuery
+	FilterUID string
+}
+
+type ListPartyHostingLimitsResponse struct {
+	Results []*PartyHostingLimitsResult
+}
+
+type ListTopologyVettedPackagesRequest struct {
+	BaseQuery         *BaseQuery
+	FilterParticipant string
+}
+
+type ListTopologyVettedPackagesResponse struct {
+	Results []*VettedPackagesResult
+}
+
+type ListSynchronizerParametersStateRequest struct {
+	BaseQuery            *BaseQuery
+	FilterSynchronizerID string
+}
+
+type ListSynchronizerParametersStateResponse struct {
+	Results []*SynchronizerParametersStateResult
+}
+
+type ListSequencingParametersStateRequest struct {
+	BaseQuery            *BaseQuery
+	FilterSynchronizerID string
+}
+
+type ListSequencingParametersStateResponse struct {
+	Results []*SequencingParametersStateResult
+}
+
+type ListMediatorSynchronizerStateRequest struct {
+	BaseQuery            *BaseQuery
+	FilterSynchronizerID string
+}
+
+type ListMediatorSynchronizerStateResponse struct {
+	Results []*MediatorSynchronizerStateResult
+}
+
+type ListSequencerSynchronizerStateRequest struct {
+	BaseQuery            *BaseQuery
+	FilterSynchronizerID string
+}
+
+type ListSequencerSynchronizerStateResponse struct {
+	Results []*SequencerSynchronizerStateResult
+}
+
+type ListAllRequest struct {
+	BaseQuery *BaseQuery
+	// ExcludeMappings lists mapping codes to leave out, e.g. "vtp".
+	ExcludeMappings []string
+	FilterNamespace string
+}
+
+type ListAllResponse struct {
+	Transactions []*StoredTopologyTransaction
+}
+
+// StoredTopologyTransaction is a signed topology transaction together with its
+// validity in a topology store.
+type StoredTopologyTransaction struct {
+	Sequenced       *time.Time
+	ValidFrom       *time.Time
+	ValidUntil      *time.Time
+	Transaction     *SignedTopologyTransaction
+	RejectionReason string
+}
+
 type BaseQuery struct {
 	Store           *StoreID
 	Proposals       bool
@@ -108,9 +220,10 @@ type MultiTransactionSignatures struct {
 }
 
 type TopologyTransactionSignature struct {
-	SignedBy        string
-	Signature       []byte
-	SignatureFormat int32
+	SignedBy             string
+	Signature            []byte
+	SignatureFormat      int32
+	SigningAlgorithmSpec SigningAlgorithmSpec
 }
 
 type TopologyTransactionProposal struct {
@@ -233,6 +346,181 @@ func (*PartyToParticipantMapping) isTopologyMapping() {}
 type HostingParticipant struct {
 	ParticipantUID string
 	Permission     ParticipantPermission
+	// Onboarding marks a participant the party is still being replicated to.
+	Onboarding bool
+}
+
+type DecentralizedNamespaceDefinitionResult struct {
+	Context *BaseResult
+	Item    *DecentralizedNamespaceDefinitionMapping
+}
+
+type DecentralizedNamespaceDefinitionMapping struct {
+	Namespace string
+	Threshold int32
+	// Owners are the namespaces jointly controlling the decentralized namespace.
+	Owners []string
+}
+
+func (*DecentralizedNamespaceDefinitionMapping) isTopologyMapping() {}
+
+type OwnerToKeyMappingResult struct {
+	Context *BaseResult
+	Item    *OwnerToKeyMapping
+}
+
+// OwnerToKeyMapping holds the keys of a node (participant, mediator or
+// sequencer). Member is the member ID, e.g. "PAR::<uid>".
+type OwnerToKeyMapping struct {
+	Member         string
+	SigningKeys    []PublicKey
+	EncryptionKeys []PublicKey
+}
+
+func (*OwnerToKeyMapping) isTopologyMapping() {}
+
+type SynchronizerTrustCertificateResult struct {
+	Context *BaseResult
+	Item    *SynchronizerTrustCertificateMapping
+}
+
+type SynchronizerTrustCertificateMapping struct {
+	ParticipantUID string
+	SynchronizerID string
+	FeatureFlags   []ParticipantFeatureFlag
+}
+
+func (*SynchronizerTrustCertificateMapping) isTopologyMapping() {}
+
+type ParticipantFeatureFlag int32
+
+const (
+	ParticipantFeatureFlagUnspecified                               ParticipantFeatureFlag = 0
+	ParticipantFeatureFlagPV33ExternalSigningLocalContractInSubview ParticipantFeatureFlag = 1
+	ParticipantFeatureFlagEnableAlphaMultiSynchronizer              ParticipantFeatureFlag = 2
+)
+
+type ParticipantSynchronizerPermissionResult struct {
+	Context *BaseResult
+	Item    *ParticipantSynchronizerPermissionMapping
+}
+
+type ParticipantSynchronizerPermissionMapping struct {
+	SynchronizerID string
+	ParticipantUID string
+	Permission     ParticipantPermission
+	// ConfirmationRequestsMaxRate is nil when the participant has no limits.
+	ConfirmationRequestsMaxRate *uint32
+	// LoginAfter is the earliest time, in microseconds since the epoch, at which
+	// the participant may reconnect.
+	LoginAfter *int64
+}
+
+type PartyHostingLimitsResult struct {
+	Context *BaseResult
+	Item    *PartyHostingLimitsMapping
+}
+
+type PartyHostingLimitsMapping struct {
+	SynchronizerID string
+	Party          string
+}
+
+func (*PartyHostingLimitsMapping) isTopologyMapping() {}
+
+type VettedPackagesResult struct {
+	Context *BaseResult
+	Item    *VettedPackagesMapping
+}
+
+type VettedPackagesMapping struct {
+	ParticipantUID string
+	Packages       []*TopologyVettedPackage
+}
+
+func (*VettedPackagesMapping) isTopologyMapping() {}
+
+type TopologyVettedPackage struct {
+	PackageID           string
+	ValidFromInclusive  *time.Time
+	ValidUntilExclusive *time.Time
+}
+
+type SynchronizerParametersStateResult struct {
+	Context *BaseResult
+	Item    *SynchronizerParameters
+}
+
+type SynchronizerParameters struct {
+	ConfirmationResponseTimeout         time.Duration
+	MediatorReactionTimeout             time.Duration
+	AssignmentExclusivityTimeout        time.Duration
+	LedgerTimeRecordTimeTolerance       time.Duration
+	ReconciliationInterval              time.Duration
+	MediatorDeduplicationTimeout        time.Duration
+	SequencerAggregateSubmissionTimeout time.Duration
+	PreparationTimeRecordTimeTolerance  time.Duration
+	MaxRequestSize                      uint32
+	OnboardingRestriction               OnboardingRestriction
+	ConfirmationRequestsMaxRate         uint32
+	TrafficControl                      *TrafficControlParameters
+	AcsCommitmentsCatchUp               *AcsCommitmentsCatchUpConfig
+}
+
+type OnboardingRestriction int32
+
+const (
+	OnboardingRestrictionUnspecified        OnboardingRestriction = 0
+	OnboardingRestrictionUnrestrictedOpen   OnboardingRestriction = 1
+	OnboardingRestrictionUnrestrictedLocked OnboardingRestriction = 2
+	OnboardingRestrictionRestrictedOpen     OnboardingRestriction = 3
+	OnboardingRestrictionRestrictedLocked   OnboardingRestriction = 4
+)
+
+type TrafficControlParameters struct {
+	MaxBaseTrafficAmount                  uint64
+	MaxBaseTrafficAccumulationDuration    time.Duration
+	ReadVsWriteScalingFactor              uint32
+	SetBalanceRequestSubmissionWindowSize time.Duration
+	EnforceRateLimiting                   bool
+	BaseEventCost                         *uint64
+	FreeConfirmationResponses             bool
+}
+
+type AcsCommitmentsCatchUpConfig struct {
+	CatchUpIntervalSkip         uint32
+	NrIntervalsToTriggerCatchUp uint32
+}
+
+type SequencingParametersStateResult struct {
+	Context *BaseResult
+	// Payload holds the opaque, sequencer-specific parameters.
+	Payload []byte
+}
+
+type MediatorSynchronizerStateResult struct {
+	Context *BaseResult
+	Item    *MediatorSynchronizerStateMapping
+}
+
+type MediatorSynchronizerStateMapping struct {
+	SynchronizerID string
+	Group          uint32
+	Threshold      uint32
+	Active         []string
+	Observers      []string
+}
+
+type SequencerSynchronizerStateResult struct {
+	Context *BaseResult
+	Item    *SequencerSynchronizerStateMapping
+}
+
+type SequencerSynchronizerStateMapping struct {
+	SynchronizerID string
+	Threshold      uint32
+	Active         []string
+	Observers      []string
 }
 
 type BaseResult struct {
@@ -296,6 +584,20 @@ type DropTemporaryTopologyStoreRequest struct {
 
 type DropTemporaryTopologyStoreResponse struct{}
 
+type ExportTopologySnapshotRequest struct {
+	BaseQuery       *BaseQuery
+	ExcludeMappings []string
+	FilterNamespace string
+}
+
+type GenesisStateRequest struct {
+	// SynchronizerStore must be set on participants and may be omitted on
+	// sequencers and mediators.
+	SynchronizerStore *StoreID
+	// Timestamp defaults to the current topology state when nil.
+	Timestamp *time.Time
+}
+
 type ImportTopologySnapshotRequest struct {
 	TopologySnapshot      []byte
 	Store                 *StoreID
@@ -303,3 +605,68 @@ type ImportTopologySnapshotRequest struct {
 }
 
 type ImportTopologySnapshotResponse struct{}
+
+type ListPartiesRequest struct {
+	// AsOf defaults to the current topology state when nil.
+	AsOf              *time.Time
+	Limit             int32
+	SynchronizerIDs   []string
+	FilterParty       string
+	FilterParticipant string
+}
+
+type ListPartiesResponse struct {
+	Results []*PartyHosting
+}
+
+// PartyHosting lists the participants hosting a party across synchronizers.
+type PartyHosting struct {
+	Party        string
+	Participants []*PartyHostingParticipant
+}
+
+type PartyHostingParticipant struct {
+	ParticipantUID string
+	Synchronizers  []*SynchronizerPermission
+}
+
+type SynchronizerPermission struct {
+	SynchronizerID         string
+	PhysicalSynchronizerID string
+	Permission             ParticipantPermission
+}
+
+type ListKeyOwnersRequest struct {
+	AsOf               *time.Time
+	Limit              int32
+	SynchronizerIDs    []string
+	FilterKeyOwnerType string
+	FilterKeyOwnerUID  string
+}
+
+type ListKeyOwnersResponse struct {
+	Results []*KeyOwnerKeys
+}
+
+type KeyOwnerKeys struct {
+	SynchronizerID         string
+	PhysicalSynchronizerID string
+	KeyOwner               string
+	SigningKeys            []PublicKey
+	EncryptionKeys         []PublicKey
+}
+
+type InitIDRequest struct {
+	// Identifier is the node name, e.g. "participant1".
+	Identifier string
+	// Namespace defaults to the namespace of the first delegation.
+	Namespace string
+	// NamespaceDelegations are required when the root namespace key is held
+	// outside the node.
+	NamespaceDelegations []*SignedTopologyTransaction
+}
+
+type GetIDResponse struct {
+	Initialized      bool
+	UniqueIdentifier string
+}
@@ -0,0 +1,93 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type ActionType string
+
+const (
+	ActionCreate ActionType = "create"
+	ActionUpdate ActionType = "update"
+	ActionMove   ActionType = "move"
+	ActionGrant  ActionType = "grant"
+	ActionRevoke ActionType = "revoke"
+	ActionUpload ActionType = "upload"
+	ActionVet    ActionType = "vet"
+	ActionUnvet  ActionType = "unvet"
+)
+
+type ResourceKind string
+
+const (
+	ResourceIdentityProvider ResourceKind = "identity-provider"
+	ResourceParty            ResourceKind = "party"
+	ResourceUser             ResourceKind = "user"
+	ResourceDar              ResourceKind = "dar"
+)
+
+// Action is a single change needed to converge the participant to the spec.
+type Action struct {
+	Type     ActionType
+	Resource ResourceKind
+	Name     string
+	// Changes describes what the action changes, one entry per field or right.
+	Changes []string
+
+	run func(ctx context.Context) error
+}
+
+func (a *Action) String() string {
+	s := fmt.Sprintf("%s %s %s", a.Type, a.Resource, a.Name)
+	if len(a.Changes) > 0 {
+		s += ": " + strings.Join(a.Changes, ", ")
+	}
+	return s
+}
+
+// Plan is the ordered list of actions computed by Reconciler.Plan. An empty
+// plan means the participant already matches the spec.
+type Plan struct {
+	Actions []*Action
+
+	// parties maps party hints to party IDs. Hints of parties that are still to
+	// be allocated are resolved while the plan is applied.
+	parties map[string]string
+	// uploads holds the DARs to be uploaded, for validation on dry runs.
+	uploads [][]byte
+}
+
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "no changes"
+	}
+
+	var b strings.Builder
+	for _, a := range p.Actions {
+		b.WriteString(a.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (p *Plan) add(a *Action) {
+	p.Actions = append(p.Actions, a)
+}
+
+// resolveParty returns the party ID for a hint or full party ID.
+func (p *Plan) resolveParty(ref string) (string, error) {
+	if isPartyID(ref) {
+		return ref, nil
+	}
+	id, ok := p.parties[ref]
+	if !ok {
+		return "", fmt.Errorf("party %q is not allocated", ref)
+	}
+	return id, nil
+}
@@ -0,0 +1,659 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/noders-team/go-daml/pkg/client"
+	"github.com/noders-team/go-daml/pkg/dar"
+	"github.com/noders-team/go-daml/pkg/model"
+)
+
+// Reconciler converges a participant to a Spec using the Ledger API admin
+// services of a binding client.
+type Reconciler struct {
+	cl *client.DamlBindingClient
+}
+
+func NewReconciler(cl *client.DamlBindingClient) *Reconciler {
+	return &Reconciler{
+		cl: cl,
+	}
+}
+
+// Reconcile computes the plan for spec and applies it. With dryRun nothing is
+// changed; DARs that would be uploaded are validated by the participant
+// instead. The returned plan lists the actions taken, or that would be taken.
+func (r *Reconciler) Reconcile(ctx context.Context, spec *Spec, dryRun bool) (*Plan, error) {
+	plan, err := r.Plan(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		for _, data := range plan.uploads {
+			if err := r.cl.PackageMng.ValidateDarFile(ctx, data, ""); err != nil {
+				return plan, fmt.Errorf("dar validation failed: %w", err)
+			}
+		}
+		return plan, nil
+	}
+
+	return plan, r.Apply(ctx, plan)
+}
+
+// Apply executes the actions of a plan in order and stops at the first error.
+// Plans are computed against the state at planning time; apply them promptly.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) error {
+	for _, a := range plan.Actions {
+		if err := a.run(ctx); err != nil {
+			return fmt.Errorf("failed to %s: %w", a, err)
+		}
+	}
+	return nil
+}
+
+// Plan compares the participant with spec and returns the actions needed to
+// converge it. It does not modify the participant.
+func (r *Reconciler) Plan(ctx context.Context, spec *Spec) (*Plan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		parties: make(map[string]string),
+	}
+
+	if err := r.planIdentityProviders(ctx, spec, plan); err != nil {
+		return nil, err
+	}
+	if err := r.planParties(ctx, spec, plan); err != nil {
+		return nil, err
+	}
+	if err := r.planUsers(ctx, spec, plan); err != nil {
+		return nil, err
+	}
+	if err := r.planDars(ctx, spec, plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (r *Reconciler) planIdentityProviders(ctx context.Context, spec *Spec, plan *Plan) error {
+	if len(spec.IdentityProviders) == 0 {
+		return nil
+	}
+
+	configs, err := r.cl.IdentityProviderMng.ListIdentityProviderConfigs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list identity providers: %w", err)
+	}
+	existing := make(map[string]*model.IdentityProviderConfig, len(configs))
+	for _, cfg := range configs {
+		existing[cfg.IdentityProviderID] = cfg
+	}
+
+	for _, want := range spec.IdentityProviders {
+		cfg := &model.IdentityProviderConfig{
+			IdentityProviderID: want.ID,
+			IsDeactivated:      want.Deactivated,
+			Issuer:             want.Issuer,
+			JwksURL:            want.JwksURL,
+			Audience:           want.Audience,
+		}
+
+		have, ok := existing[want.ID]
+		if !ok {
+			plan.add(&Action{
+				Type:     ActionCreate,
+				Resource: ResourceIdentityProvider,
+				Name:     want.ID,
+				run: func(ctx context.Context) error {
+					_, err := r.cl.IdentityProviderMng.CreateIdentityProviderConfig(ctx, cfg)
+					return err
+				},
+			})
+			continue
+		}
+
+		d := &fieldDiff{}
+		d.compare(model.IdentityProviderUpdatePathIsDeactivated, have.IsDeactivated, cfg.IsDeactivated)
+		d.compare(model.IdentityProviderUpdatePathIssuer, have.Issuer, cfg.Issuer)
+		d.compare(model.IdentityProviderUpdatePathJwksURL, have.JwksURL, cfg.JwksURL)
+		d.compare(model.IdentityProviderUpdatePathAudience, have.Audience, cfg.Audience)
+		if len(d.paths) == 0 {
+			continue
+		}
+
+		plan.add(&Action{
+			Type:     ActionUpdate,
+			Resource: ResourceIdentityProvider,
+			Name:     want.ID,
+			Changes:  d.changes,
+			run: func(ctx context.Context) error {
+				_, err := r.cl.IdentityProviderMng.UpdateIdentityProviderConfig(ctx, cfg, d.paths)
+				return err
+			},
+		})
+	}
+
+	return nil
+}
+
+func (r *Reconciler) planParties(ctx context.Context, spec *Spec, plan *Plan) error {
+	if len(spec.Parties) == 0 {
+		return nil
+	}
+
+	idps := []string{""}
+	for _, p := range spec.Parties {
+		if !slices.Contains(idps, p.IdentityProviderID) {
+			idps = append(idps, p.IdentityProviderID)
+		}
+	}
+
+	known := make(map[string]*model.PartyDetails)
+	for _, idp := range idps {
+		if err := r.listParties(ctx, idp, known); err != nil {
+			return fmt.Errorf("failed to list parties: %w", err)
+		}
+	}
+
+	for _, want := range spec.Parties {
+		have := findParty(known, want.Hint)
+		if have == nil {
+			plan.add(&Action{
+				Type:     ActionCreate,
+				Resource: ResourceParty,
+				Name:     want.Hint,
+				Changes:  annotationChanges(nil, want.Annotations),
+				run: func(ctx context.Context) error {
+					details, err := r.cl.PartyMng.AllocateParty(ctx, want.Hint, want.Annotations, want.IdentityProviderID)
+					if err != nil {
+						return err
+					}
+					plan.parties[want.Hint] = details.Party
+					return nil
+				},
+			})
+			continue
+		}
+
+		plan.parties[want.Hint] = have.Party
+
+		if have.IdentityProviderID != want.IdentityProviderID {
+			plan.add(&Action{
+				Type:     ActionMove,
+				Resource: ResourceParty,
+				Name:     have.Party,
+				Changes:  []string{fmt.Sprintf("identity provider: %q -> %q", have.IdentityProviderID, want.IdentityProviderID)},
+				run: func(ctx context.Context) error {
+					return r.cl.PartyMng.UpdatePartyIdentityProviderID(ctx, have.Party, have.IdentityProviderID, want.IdentityProviderID)
+				},
+			})
+		}
+
+		if changes := annotationChanges(have.LocalMetadata, want.Annotations); len(changes) > 0 {
+			plan.add(&Action{
+				Type:     ActionUpdate,
+				Resource: ResourceParty,
+				Name:     have.Party,
+				Changes:  changes,
+				run: func(ctx context.Context) error {
+					_, err := r.cl.PartyMng.UpdatePartyDetails(ctx, &model.PartyDetails{
+						Party:              have.Party,
+						LocalMetadata:      want.Annotations,
+						IdentityProviderID: want.IdentityProviderID,
+					}, &model.UpdateMask{Paths: []string{model.PartyUpdatePathAnnotations}})
+					return err
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) listParties(ctx context.Context, identityProviderID string, known map[string]*model.PartyDetails) error {
+	pageToken := ""
+	for {
+		resp, err := r.cl.PartyMng.ListKnownParties(ctx, pageToken, 0, identityProviderID)
+		if err != nil {
+			return err
+		}
+		for _, p := range resp.PartyDetails {
+			if prev, ok := known[p.Party]; !ok || prev.IdentityProviderID == "" {
+				known[p.Party] = p
+			}
+		}
+		if resp.NextPageToken == "" {
+			return nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func findParty(known map[string]*model.PartyDetails, hint string) *model.PartyDetails {
+	for _, id := range slices.Sorted(maps.Keys(known)) {
+		if p := known[id]; p.IsLocal && strings.HasPrefix(id, hint+"::") {
+			return p
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) planUsers(ctx context.Context, spec *Spec, plan *Plan) error {
+	for _, want := range spec.Users {
+		have, err := r.findUser(ctx, want)
+		if status.Code(err) == codes.NotFound {
+			plan.add(&Action{
+				Type:     ActionCreate,
+				Resource: ResourceUser,
+				Name:     want.ID,
+				Changes:  rightChanges(wantedRights(want)),
+				run: func(ctx context.Context) error {
+					user, err := desiredUser(plan, want)
+					if err != nil {
+						return err
+					}
+					rights, err := resolveRights(plan, wantedRights(want))
+					if err != nil {
+						return err
+					}
+					_, err = r.cl.UserMng.CreateUser(ctx, user, rights)
+					return err
+				},
+			})
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get user %s: %w", want.ID, err)
+		}
+
+		if have.IdentityProviderID != want.IdentityProviderID {
+			plan.add(&Action{
+				Type:     ActionMove,
+				Resource: ResourceUser,
+				Name:     want.ID,
+				Changes:  []string{fmt.Sprintf("identity provider: %q -> %q", have.IdentityProviderID, want.IdentityProviderID)},
+				run: func(ctx context.Context) error {
+					return r.cl.UserMng.UpdateUserIdentityProviderID(ctx, want.ID, have.IdentityProviderID, want.IdentityProviderID)
+				},
+			})
+		}
+
+		d := &fieldDiff{}
+		if want.PrimaryParty != "" {
+			if id, err := plan.resolveParty(want.PrimaryParty); err != nil || id != have.PrimaryParty {
+				d.compare(model.UserUpdatePathPrimaryParty, have.PrimaryParty, want.PrimaryParty)
+			}
+		}
+		d.compare(model.UserUpdatePathIsDeactivated, have.IsDeactivated, want.Deactivated)
+		if changes := annotationChanges(have.Metadata, want.Annotations); len(changes) > 0 {
+			d.paths = append(d.paths, model.UserUpdatePathAnnotations)
+			d.changes = append(d.changes, changes...)
+		}
+		if len(d.paths) > 0 {
+			plan.add(&Action{
+				Type:     ActionUpdate,
+				Resource: ResourceUser,
+				Name:     want.ID,
+				Changes:  d.changes,
+				run: func(ctx context.Context) error {
+					user, err := desiredUser(plan, want)
+					if err != nil {
+						return err
+					}
+					_, err = r.cl.UserMng.UpdateUser(ctx, user, &model.UpdateMask{Paths: d.paths})
+					return err
+				},
+			})
+		}
+
+		// listed before the move runs, so in the provider the user is in now
+		haveRights, err := r.cl.UserMng.ListUserRights(ctx, want.ID, have.IdentityProviderID)
+		if err != nil {
+			return fmt.Errorf("failed to list rights of user %s: %w", want.ID, err)
+		}
+		if err := r.planRights(plan, want, haveRights); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findUser looks the user up in its wanted identity provider and then in the
+// default one, where users not yet moved live. The participant only returns a
+// user when asked in the provider it belongs to.
+func (r *Reconciler) findUser(ctx context.Context, want UserSpec) (*model.User, error) {
+	user, err := r.cl.UserMng.GetUser(ctx, want.ID, want.IdentityProviderID)
+	if status.Code(err) == codes.NotFound && want.IdentityProviderID != "" {
+		return r.cl.UserMng.GetUser(ctx, want.ID, "")
+	}
+	return user, err
+}
+
+func (r *Reconciler) planRights(plan *Plan, want UserSpec, haveRights []*model.Right) error {
+	have := make(map[string]*model.Right, len(haveRights))
+	for _, right := range haveRights {
+		if key := rightKey(right); key != "" {
+			have[key] = right
+		}
+	}
+
+	var grants []rightRef
+	wanted := make(map[string]bool)
+	for _, ref := range wantedRights(want) {
+		right, err := ref.resolve(plan)
+		if err != nil {
+			grants = append(grants, ref)
+			continue
+		}
+		key := rightKey(right)
+		wanted[key] = true
+		if _, ok := have[key]; !ok {
+			grants = append(grants, ref)
+		}
+	}
+
+	var revokes []*model.Right
+	var revokeChanges []string
+	for _, key := range slices.Sorted(maps.Keys(have)) {
+		if !wanted[key] {
+			revokes = append(revokes, have[key])
+			revokeChanges = append(revokeChanges, key)
+		}
+	}
+
+	if len(grants) > 0 {
+		plan.add(&Action{
+			Type:     ActionGrant,
+			Resource: ResourceUser,
+			Name:     want.ID,
+			Changes:  rightChanges(grants),
+			run: func(ctx context.Context) error {
+				rights, err := resolveRights(plan, grants)
+				if err != nil {
+					return err
+				}
+				_, err = r.cl.UserMng.GrantUserRights(ctx, want.ID, want.IdentityProviderID, rights)
+				return err
+			},
+		})
+	}
+
+	if len(revokes) > 0 {
+		plan.add(&Action{
+			Type:     ActionRevoke,
+			Resource: ResourceUser,
+			Name:     want.ID,
+			Changes:  revokeChanges,
+			run: func(ctx context.Context) error {
+				_, err := r.cl.UserMng.RevokeUserRights(ctx, want.ID, want.IdentityProviderID, revokes)
+				return err
+			},
+		})
+	}
+
+	return nil
+}
+
+func desiredUser(plan *Plan, want UserSpec) (*model.User, error) {
+	user := &model.User{
+		ID:                 want.ID,
+		IsDeactivated:      want.Deactivated,
+		Metadata:           want.Annotations,
+		IdentityProviderID: want.IdentityProviderID,
+	}
+	if want.PrimaryParty != "" {
+		party, err := plan.resolveParty(want.PrimaryParty)
+		if err != nil {
+			return nil, err
+		}
+		user.PrimaryParty = party
+	}
+	return user, nil
+}
+
+// rightRef is a wanted right whose party may still be a hint.
+type rightRef struct {
+	kind  string
+	party string
+}
+
+const (
+	rightActAs                 = "actAs"
+	rightReadAs                = "readAs"
+	rightParticipantAdmin      = "participantAdmin"
+	rightIdentityProviderAdmin = "identityProviderAdmin"
+)
+
+func wantedRights(want UserSpec) []rightRef {
+	var refs []rightRef
+	for _, p := range want.ActAs {
+		refs = append(refs, rightRef{kind: rightActAs, party: p})
+	}
+	for _, p := range want.ReadAs {
+		refs = append(refs, rightRef{kind: rightReadAs, party: p})
+	}
+	if want.ParticipantAdmin {
+		refs = append(refs, rightRef{kind: rightParticipantAdmin})
+	}
+	if want.IdentityProviderAdmin {
+		refs = append(refs, rightRef{kind: rightIdentityProviderAdmin})
+	}
+	return refs
+}
+
+func (ref rightRef) resolve(plan *Plan) (*model.Right, error) {
+	switch ref.kind {
+	case rightActAs, rightReadAs:
+		party, err := plan.resolveParty(ref.party)
+		if err != nil {
+			return nil, err
+		}
+		if ref.kind == rightActAs {
+			return &model.Right{Type: model.CanActAs{Party: party}}, nil
+		}
+		return &model.Right{Type: model.CanReadAs{Party: party}}, nil
+	case rightParticipantAdmin:
+		return &model.Right{Type: model.ParticipantAdmin{}}, nil
+	default:
+		return &model.Right{Type: model.IdentityProviderAdmin{}}, nil
+	}
+}
+
+func (ref rightRef) String() string {
+	if ref.party == "" {
+		return ref.kind
+	}
+	return ref.kind + ":" + ref.party
+}
+
+func resolveRights(plan *Plan, refs []rightRef) ([]*model.Right, error) {
+	rights := make([]*model.Right, len(refs))
+	for i, ref := range refs {
+		right, err := ref.resolve(plan)
+		if err != nil {
+			return nil, err
+		}
+		rights[i] = right
+	}
+	return rights, nil
+}
+
+func rightChanges(refs []rightRef) []string {
+	changes := make([]string, len(refs))
+	for i, ref := range refs {
+		changes[i] = ref.String()
+	}
+	return changes
+}
+
+// rightKey identifies a right for comparison. Rights the model cannot
+// represent get an empty key and are never revoked.
+func rightKey(right *model.Right) string {
+	if right == nil {
+		return ""
+	}
+	switch t := right.Type.(type) {
+	case model.CanActAs:
+		return rightActAs + ":" + t.Party
+	case model.CanReadAs:
+		return rightReadAs + ":" + t.Party
+	case model.ParticipantAdmin:
+		return rightParticipantAdmin
+	case model.IdentityProviderAdmin:
+		return rightIdentityProviderAdmin
+	default:
+		return ""
+	}
+}
+
+func (r *Reconciler) planDars(ctx context.Context, spec *Spec, plan *Plan) error {
+	if len(spec.Dars) == 0 {
+		return nil
+	}
+
+	packages, err := r.cl.PackageMng.ListKnownPackages(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list packages: %w", err)
+	}
+	known := make(map[string]bool, len(packages))
+	for _, p := range packages {
+		known[p.PackageID] = true
+	}
+
+	participantID, err := r.cl.PartyMng.GetParticipantID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get participant ID: %w", err)
+	}
+
+	for i, want := range spec.Dars {
+		data, err := want.read()
+		if err != nil {
+			return fmt.Errorf("failed to read dar #%d: %w", i, err)
+		}
+		info, err := dar.Inspect(data)
+		if err != nil {
+			return fmt.Errorf("failed to inspect dar #%d: %w", i, err)
+		}
+		packageID := info.MainPackageID
+
+		name := want.Path
+		if name == "" {
+			name = packageID
+		}
+
+		if !known[packageID] {
+			plan.uploads = append(plan.uploads, data)
+			plan.add(&Action{
+				Type:     ActionUpload,
+				Resource: ResourceDar,
+				Name:     name,
+				Changes:  []string{"main package " + packageID},
+				run: func(ctx context.Context) error {
+					return r.cl.PackageMng.UploadDarFile(ctx, data, "")
+				},
+			})
+			// Uploads vet all packages of the DAR.
+			if !want.vet() {
+				plan.add(r.vettingAction(ActionUnvet, name, packageID))
+			}
+			continue
+		}
+
+		vetted, err := r.isVetted(ctx, participantID, packageID)
+		if err != nil {
+			return fmt.Errorf("failed to list vetted packages: %w", err)
+		}
+		if vetted != want.vet() {
+			actionType := ActionVet
+			if vetted {
+				actionType = ActionUnvet
+			}
+			plan.add(r.vettingAction(actionType, name, packageID))
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) isVetted(ctx context.Context, participantID, packageID string) (bool, error) {
+	req := &model.ListVettedPackagesRequest{
+		PackageMetadataFilter: &model.PackageMetadataFilter{PackageIDs: []string{packageID}},
+		TopologyStateFilter:   &model.TopologyStateFilter{ParticipantIDs: []string{participantID}},
+	}
+	for {
+		resp, err := r.cl.PackageService.ListVettedPackages(ctx, req)
+		if err != nil {
+			return false, err
+		}
+		for _, vp := range resp.VettedPackages {
+			for _, p := range vp.Packages {
+				if p.PackageID == packageID {
+					return true, nil
+				}
+			}
+		}
+		if resp.NextPageToken == "" {
+			return false, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+func (r *Reconciler) vettingAction(actionType ActionType, name, packageID string) *Action {
+	refs := []*model.VettedPackagesRef{{PackageID: packageID}}
+	change := &model.VettedPackagesChange{}
+	if actionType == ActionVet {
+		change.Vet = &model.VettedPackagesVet{Packages: refs}
+	} else {
+		change.Unvet = &model.VettedPackagesUnvet{Packages: refs}
+	}
+
+	return &Action{
+		Type:     actionType,
+		Resource: ResourceDar,
+		Name:     name,
+		Changes:  []string{"main package " + packageID},
+		run: func(ctx context.Context) error {
+			_, err := r.cl.PackageMng.UpdateVettedPackages(ctx, &model.UpdateVettedPackagesRequest{
+				Changes: []*model.VettedPackagesChange{change},
+			})
+			return err
+		},
+	}
+}
+
+type fieldDiff struct {
+	paths   []string
+	changes []string
+}
+
+func (d *fieldDiff) compare(path string, have, want any) {
+	if have != want {
+		d.paths = append(d.paths, path)
+		d.changes = append(d.changes, fmt.Sprintf("%s: %v -> %v", path, have, want))
+	}
+}
+
+// annotationChanges lists the wanted annotations that differ from have.
+// Annotations not present in want are kept as they are.
+func annotationChanges(have, want map[string]string) []string {
+	var changes []string
+	for _, k := range slices.Sorted(maps.Keys(want)) {
+		if v, ok := have[k]; !ok || v != want[k] {
+			changes = append(changes, fmt.Sprintf("annotation %s=%s", k, want[k]))
+		}
+	}
+	return changes
+}
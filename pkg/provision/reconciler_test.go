@@ -0,0 +1,269 @@
+package provision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/noders-team/go-daml/pkg/client"
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/admin"
+)
+
+const namespace = "::1220abcd"
+
+type fakeIdentityProviders struct {
+	admin.IdentityProviderConfig
+	configs map[string]*model.IdentityProviderConfig
+}
+
+func (f *fakeIdentityProviders) ListIdentityProviderConfigs(ctx context.Context) ([]*model.IdentityProviderConfig, error) {
+	var result []*model.IdentityProviderConfig
+	for _, cfg := range f.configs {
+		result = append(result, cfg)
+	}
+	return result, nil
+}
+
+func (f *fakeIdentityProviders) CreateIdentityProviderConfig(ctx context.Context, cfg *model.IdentityProviderConfig) (*model.IdentityProviderConfig, error) {
+	f.configs[cfg.IdentityProviderID] = cfg
+	return cfg, nil
+}
+
+type fakeParties struct {
+	admin.PartyManagement
+	parties map[string]*model.PartyDetails
+}
+
+func (f *fakeParties) ListKnownParties(ctx context.Context, pageToken string, pageSize int32, identityProviderID string) (*model.ListKnownPartiesResponse, error) {
+	resp := &model.ListKnownPartiesResponse{}
+	for _, p := range f.parties {
+		resp.PartyDetails = append(resp.PartyDetails, p)
+	}
+	return resp, nil
+}
+
+func (f *fakeParties) AllocateParty(ctx context.Context, hint string, localMetadata map[string]string, identityProviderID string) (*model.PartyDetails, error) {
+	p := &model.PartyDetails{Party: hint + namespace, IsLocal: true, LocalMetadata: localMetadata, IdentityProviderID: identityProviderID}
+	f.parties[p.Party] = p
+	return p, nil
+}
+
+func (f *fakeParties) UpdatePartyDetails(ctx context.Context, party *model.PartyDetails, updateMask *model.UpdateMask) (*model.PartyDetails, error) {
+	p := f.parties[party.Party]
+	for k, v := range party.LocalMetadata {
+		p.LocalMetadata[k] = v
+	}
+	return p, nil
+}
+
+type fakeUsers struct {
+	admin.UserManagement
+	users  map[string]*model.User
+	rights map[string][]*model.Right
+}
+
+// user returns the user if it belongs to identityProviderID, as the
+// participant only finds users in the provider a request names.
+func (f *fakeUsers) user(userID, identityProviderID string) (*model.User, error) {
+	u, ok := f.users[userID]
+	if !ok || u.IdentityProviderID != identityProviderID {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return u, nil
+}
+
+func (f *fakeUsers) GetUser(ctx context.Context, userID, identityProviderID string) (*model.User, error) {
+	return f.user(userID, identityProviderID)
+}
+
+func (f *fakeUsers) CreateUser(ctx context.Context, user *model.User, rights []*model.Right) (*model.User, error) {
+	if _, ok := f.users[user.ID]; ok {
+		return nil, status.Error(codes.AlreadyExists, "user already exists")
+	}
+	f.users[user.ID] = user
+	f.rights[user.ID] = rights
+	return user, nil
+}
+
+func (f *fakeUsers) UpdateUserIdentityProviderID(ctx context.Context, userID string, sourceIdentityProviderID string, targetIdentityProviderID string) error {
+	u, err := f.user(userID, sourceIdentityProviderID)
+	if err != nil {
+		return err
+	}
+	u.IdentityProviderID = targetIdentityProviderID
+	return nil
+}
+
+func (f *fakeUsers) ListUserRights(ctx context.Context, userID, identityProviderID string) ([]*model.Right, error) {
+	if _, err := f.user(userID, identityProviderID); err != nil {
+		return nil, err
+	}
+	return f.rights[userID], nil
+}
+
+func (f *fakeUsers) GrantUserRights(ctx context.Context, userID, identityProviderID string, rights []*model.Right) ([]*model.Right, error) {
+	if _, err := f.user(userID, identityProviderID); err != nil {
+		return nil, err
+	}
+	f.rights[userID] = append(f.rights[userID], rights...)
+	return rights, nil
+}
+
+func (f *fakeUsers) RevokeUserRights(ctx context.Context, userID, identityProviderID string, rights []*model.Right) ([]*model.Right, error) {
+	if _, err := f.user(userID, identityProviderID); err != nil {
+		return nil, err
+	}
+	var kept []*model.Right
+	for _, have := range f.rights[userID] {
+		revoked := false
+		for _, r := range rights {
+			if rightKey(r) == rightKey(have) {
+				revoked = true
+			}
+		}
+		if !revoked {
+			kept = append(kept, have)
+		}
+	}
+	f.rights[userID] = kept
+	return rights, nil
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	idps := &fakeIdentityProviders{configs: map[string]*model.IdentityProviderConfig{}}
+	parties := &fakeParties{parties: map[string]*model.PartyDetails{
+		"alice" + namespace: {Party: "alice" + namespace, IsLocal: true, LocalMetadata: map[string]string{}},
+	}}
+	users := &fakeUsers{
+		users: map[string]*model.User{
+			"operator": {ID: "operator", Metadata: map[string]string{}},
+		},
+		rights: map[string][]*model.Right{
+			"operator": {{Type: model.ParticipantAdmin{}}, {Type: model.CanReadAs{Party: "carol" + namespace}}},
+		},
+	}
+	cl := &client.DamlBindingClient{
+		IdentityProviderMng: idps,
+		PartyMng:            parties,
+		UserMng:             users,
+	}
+
+	spec, err := ParseSpec([]byte(`
+identityProviders:
+  - id: keycloak
+    issuer: https://auth.example.com
+    jwksUrl: https://auth.example.com/certs
+parties:
+  - hint: alice
+    annotations:
+      team: payments
+  - hint: bob
+users:
+  - id: operator
+    participantAdmin: true
+    actAs: [alice]
+  - id: bob-app
+    primaryParty: bob
+    actAs: [bob]
+    readAs: [alice]
+`))
+	require.NoError(t, err)
+
+	r := NewReconciler(cl)
+
+	plan, err := r.Reconcile(ctx, spec, true)
+	require.NoError(t, err)
+	require.Len(t, plan.Actions, 6, plan.String())
+	require.Empty(t, idps.configs, "dry run must not change the participant")
+	require.Len(t, parties.parties, 1, "dry run must not change the participant")
+
+	plan, err = r.Reconcile(ctx, spec, false)
+	require.NoError(t, err)
+	require.Len(t, plan.Actions, 6, plan.String())
+
+	require.Contains(t, idps.configs, "keycloak")
+	require.Equal(t, "payments", parties.parties["alice"+namespace].LocalMetadata["team"])
+	require.Contains(t, parties.parties, "bob"+namespace)
+	require.Equal(t, "bob"+namespace, users.users["bob-app"].PrimaryParty)
+	require.ElementsMatch(t, []*model.Right{
+		{Type: model.CanActAs{Party: "bob" + namespace}},
+		{Type: model.CanReadAs{Party: "alice" + namespace}},
+	}, users.rights["bob-app"])
+	require.ElementsMatch(t, []*model.Right{
+		{Type: model.ParticipantAdmin{}},
+		{Type: model.CanActAs{Party: "alice" + namespace}},
+	}, users.rights["operator"])
+
+	plan, err = r.Plan(ctx, spec)
+	require.NoError(t, err)
+	require.True(t, plan.Empty(), plan.String())
+}
+
+func TestReconcileUsersInIdentityProvider(t *testing.T) {
+	ctx := context.Background()
+
+	users := &fakeUsers{
+		users: map[string]*model.User{
+			"auditor": {ID: "auditor", Metadata: map[string]string{}},
+			"app":     {ID: "app", Metadata: map[string]string{}, IdentityProviderID: "okta"},
+		},
+		rights: map[string][]*model.Right{
+			"auditor": {{Type: model.ParticipantAdmin{}}},
+			"app":     {{Type: model.IdentityProviderAdmin{}}},
+		},
+	}
+	cl := &client.DamlBindingClient{
+		IdentityProviderMng: &fakeIdentityProviders{configs: map[string]*model.IdentityProviderConfig{
+			"keycloak": {IdentityProviderID: "keycloak", Issuer: "https://auth.example.com", JwksURL: "https://auth.example.com/certs"},
+			"okta":     {IdentityProviderID: "okta", Issuer: "https://okta.example.com", JwksURL: "https://okta.example.com/certs"},
+		}},
+		UserMng: users,
+	}
+
+	spec, err := ParseSpec([]byte(`
+identityProviders:
+  - id: keycloak
+    issuer: https://auth.example.com
+    jwksUrl: https://auth.example.com/certs
+  - id: okta
+    issuer: https://okta.example.com
+    jwksUrl: https://okta.example.com/certs
+users:
+  - id: auditor
+    identityProviderId: keycloak
+    identityProviderAdmin: true
+  - id: app
+    identityProviderId: okta
+    identityProviderAdmin: true
+`))
+	require.NoError(t, err)
+
+	r := NewReconciler(cl)
+
+	plan, err := r.Reconcile(ctx, spec, false)
+	require.NoError(t, err)
+	require.Len(t, plan.Actions, 3, plan.String())
+	require.Equal(t, ActionMove, plan.Actions[0].Type)
+
+	require.Equal(t, "keycloak", users.users["auditor"].IdentityProviderID)
+	require.Equal(t, []*model.Right{{Type: model.IdentityProviderAdmin{}}}, users.rights["auditor"])
+
+	plan, err = r.Plan(ctx, spec)
+	require.NoError(t, err)
+	require.True(t, plan.Empty(), plan.String())
+}
+
+func TestParseSpecRejectsUndeclaredParty(t *testing.T) {
+	_, err := ParseSpec([]byte(`
+users:
+  - id: app
+    actAs: [nobody]
+`))
+	require.ErrorContains(t, err, `undeclared party "nobody"`)
+}
@@ -0,0 +1,175 @@
+package provision
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the desired state of a participant. Resources not listed in the spec
+// are left untouched; the rights of listed users are converged exactly.
+type Spec struct {
+	IdentityProviders []IdentityProviderSpec `yaml:"identityProviders"`
+	Parties           []PartySpec            `yaml:"parties"`
+	Users             []UserSpec             `yaml:"users"`
+	Dars              []DarSpec              `yaml:"dars"`
+}
+
+type IdentityProviderSpec struct {
+	ID          string `yaml:"id"`
+	Issuer      string `yaml:"issuer"`
+	JwksURL     string `yaml:"jwksUrl"`
+	Audience    string `yaml:"audience"`
+	Deactivated bool   `yaml:"deactivated"`
+}
+
+// PartySpec describes a local party. A party matches when its ID starts with
+// the hint followed by "::".
+type PartySpec struct {
+	Hint               string            `yaml:"hint"`
+	Annotations        map[string]string `yaml:"annotations"`
+	IdentityProviderID string            `yaml:"identityProviderId"`
+}
+
+// UserSpec describes a user. Party references (PrimaryParty, ActAs, ReadAs)
+// are either full party IDs or hints of parties declared in the spec.
+type UserSpec struct {
+	ID                    string            `yaml:"id"`
+	PrimaryParty          string            `yaml:"primaryParty"`
+	Annotations           map[string]string `yaml:"annotations"`
+	Deactivated           bool              `yaml:"deactivated"`
+	IdentityProviderID    string            `yaml:"identityProviderId"`
+	ActAs                 []string          `yaml:"actAs"`
+	ReadAs                []string          `yaml:"readAs"`
+	ParticipantAdmin      bool              `yaml:"participantAdmin"`
+	IdentityProviderAdmin bool              `yaml:"identityProviderAdmin"`
+}
+
+// DarSpec describes a DAR to upload. Data takes precedence over Path when set.
+// The main package is vetted unless Vet is explicitly false.
+type DarSpec struct {
+	Path string `yaml:"path"`
+	Data []byte `yaml:"-"`
+	Vet  *bool  `yaml:"vet"`
+}
+
+func (d DarSpec) vet() bool {
+	return d.Vet == nil || *d.Vet
+}
+
+func (d DarSpec) read() ([]byte, error) {
+	if d.Data != nil {
+		return d.Data, nil
+	}
+	return os.ReadFile(d.Path)
+}
+
+// ParseSpec decodes a YAML spec. Unknown fields are rejected.
+func ParseSpec(data []byte) (*Spec, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	spec := &Spec{}
+	if err := dec.Decode(spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// LoadSpec reads a YAML spec from a file. Relative DAR paths are resolved
+// against the directory of the spec file.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := ParseSpec(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	for i, dar := range spec.Dars {
+		if dar.Path != "" && !filepath.IsAbs(dar.Path) {
+			spec.Dars[i].Path = filepath.Join(dir, dar.Path)
+		}
+	}
+
+	return spec, nil
+}
+
+// Validate checks the spec for missing identifiers, duplicates and references
+// to undeclared party hints.
+func (s *Spec) Validate() error {
+	var errs []error
+
+	idps := make(map[string]bool)
+	for _, idp := range s.IdentityProviders {
+		if idp.ID == "" {
+			errs = append(errs, errors.New("identity provider without id"))
+			continue
+		}
+		if idps[idp.ID] {
+			errs = append(errs, fmt.Errorf("duplicate identity provider %q", idp.ID))
+		}
+		idps[idp.ID] = true
+	}
+
+	hints := make(map[string]bool)
+	for _, p := range s.Parties {
+		if p.Hint == "" {
+			errs = append(errs, errors.New("party without hint"))
+			continue
+		}
+		if hints[p.Hint] {
+			errs = append(errs, fmt.Errorf("duplicate party hint %q", p.Hint))
+		}
+		hints[p.Hint] = true
+	}
+
+	users := make(map[string]bool)
+	for _, u := range s.Users {
+		if u.ID == "" {
+			errs = append(errs, errors.New("user without id"))
+			continue
+		}
+		if users[u.ID] {
+			errs = append(errs, fmt.Errorf("duplicate user %q", u.ID))
+		}
+		users[u.ID] = true
+
+		refs := append([]string{}, u.ActAs...)
+		refs = append(refs, u.ReadAs...)
+		if u.PrimaryParty != "" {
+			refs = append(refs, u.PrimaryParty)
+		}
+		for _, ref := range refs {
+			if !isPartyID(ref) && !hints[ref] {
+				errs = append(errs, fmt.Errorf("user %q references undeclared party %q", u.ID, ref))
+			}
+		}
+	}
+
+	for i, d := range s.Dars {
+		if d.Path == "" && d.Data == nil {
+			errs = append(errs, fmt.Errorf("dar #%d has neither path nor data", i))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func isPartyID(ref string) bool {
+	return strings.Contains(ref, "::")
+}
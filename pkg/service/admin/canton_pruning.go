@@ -0,0 +1,253 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	pruningconv "github.com/noders-team/go-daml/pkg/service/pruning"
+	participantv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/participant/v30"
+	pruningv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/pruning/v30"
+)
+
+type CantonPruning interface {
+	Prune(ctx context.Context, pruneUpTo int64, commitmentsState model.SafeToPruneCommitmentState) error
+	GetSafePruningOffset(ctx context.Context, req *model.GetSafePruningOffsetRequest) (*int64, error)
+	SetSchedule(ctx context.Context, schedule *model.PruningSchedule) error
+	SetParticipantSchedule(ctx context.Context, schedule *model.ParticipantPruningSchedule) error
+	SetCron(ctx context.Context, cron string) error
+	SetMaxDuration(ctx context.Context, maxDuration time.Duration) error
+	SetRetention(ctx context.Context, retention time.Duration) error
+	GetSchedule(ctx context.Context) (*model.PruningSchedule, error)
+	GetParticipantSchedule(ctx context.Context) (*model.ParticipantPruningSchedule, error)
+	ClearSchedule(ctx context.Context) error
+	SetNoWaitCommitmentsFrom(ctx context.Context, counterParticipantIDs []string, synchronizerIDs []string) error
+	ResetNoWaitCommitmentsFrom(ctx context.Context, counterParticipantIDs []string, synchronizerIDs []string) error
+	GetNoWaitCommitmentsFrom(ctx context.Context, synchronizerIDs []string, participantUIDs []string) (*model.NoWaitCommitmentsConfig, error)
+}
+
+type cantonPruning struct {
+	client participantv30.PruningServiceClient
+}
+
+func NewCantonPruningClient(conn *grpc.ClientConn) *cantonPruning {
+	client := participantv30.NewPruningServiceClient(conn)
+	return &cantonPruning{
+		client: client,
+	}
+}
+
+func (c *cantonPruning) Prune(ctx context.Context, pruneUpTo int64, commitmentsState model.SafeToPruneCommitmentState) error {
+	req := &participantv30.PruneRequest{
+		PruneUpTo:                           pruneUpTo,
+		CounterParticipantsCommitmentsState: safeToPruneCommitmentStateToProto(commitmentsState),
+	}
+
+	_, err := c.client.Prune(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cantonPruning) GetSafePruningOffset(ctx context.Context, req *model.GetSafePruningOffsetRequest) (*int64, error) {
+	protoReq := &participantv30.GetSafePruningOffsetRequest{
+		BeforeOrAt:                          timestamppb.New(req.BeforeOrAt),
+		LedgerEnd:                           req.LedgerEnd,
+		CounterParticipantsCommitmentsState: safeToPruneCommitmentStateToProto(req.CounterParticipantsCommitmentsState),
+	}
+
+	resp, err := c.client.GetSafePruningOffset(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if r, ok := resp.Response.(*participantv30.GetSafePruningOffsetResponse_SafePruningOffset); ok {
+		offset := r.SafePruningOffset
+		return &offset, nil
+	}
+
+	return nil, nil
+}
+
+func (c *cantonPruning) SetSchedule(ctx context.Context, schedule *model.PruningSchedule) error {
+	req := &pruningv30.SetScheduleRequest{
+		Schedule: pruningconv.ScheduleToProto(schedule),
+	}
+
+	_, err := c.client.SetSchedule(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cantonPruning) SetParticipantSchedule(ctx context.Context, schedule *model.ParticipantPruningSchedule) error {
+	req := &pruningv30.SetParticipantScheduleRequest{}
+	if schedule != nil {
+		req.Schedule = &pruningv30.ParticipantPruningSchedule{
+			Schedule:            pruningconv.ScheduleToProto(schedule.Schedule),
+			PruneInternallyOnly: schedule.PruneInternallyOnly,
+		}
+	}
+
+	_, err := c.client.SetParticipantSchedule(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cantonPruning) SetCron(ctx context.Context, cron string) error {
+	req := &pruningv30.SetCronRequest{
+		Cron: cron,
+	}
+
+	_, err := c.client.SetCron(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cantonPruning) SetMaxDuration(ctx context.Context, maxDuration time.Duration) error {
+	req := &pruningv30.SetMaxDurationRequest{
+		MaxDuration: durationpb.New(maxDuration),
+	}
+
+	_, err := c.client.SetMaxDuration(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cantonPruning) SetRetention(ctx context.Context, retention time.Duration) error {
+	req := &pruningv30.SetRetentionRequest{
+		Retention: durationpb.New(retention),
+	}
+
+	_, err := c.client.SetRetention(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cantonPruning) GetSchedule(ctx context.Context) (*model.PruningSchedule, error) {
+	resp, err := c.client.GetSchedule(ctx, &pruningv30.GetScheduleRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return pruningconv.ScheduleFromProto(resp.Schedule), nil
+}
+
+func (c *cantonPruning) GetParticipantSchedule(ctx context.Context) (*model.ParticipantPruningSchedule, error) {
+	resp, err := c.client.GetParticipantSchedule(ctx, &pruningv30.GetParticipantScheduleRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Schedule == nil {
+		return nil, nil
+	}
+
+	return &model.ParticipantPruningSchedule{
+		Schedule:            pruningconv.ScheduleFromProto(resp.Schedule.Schedule),
+		PruneInternallyOnly: resp.Schedule.PruneInternallyOnly,
+	}, nil
+}
+
+func (c *cantonPruning) ClearSchedule(ctx context.Context) error {
+	_, err := c.client.ClearSchedule(ctx, &pruningv30.ClearScheduleRequest{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cantonPruning) SetNoWaitCommitmentsFrom(ctx context.Context, counterParticipantIDs []string, synchronizerIDs []string) error {
+	req := &pruningv30.SetNoWaitCommitmentsFromRequest{
+		CounterParticipantIds: counterParticipantIDs,
+		SynchronizerIds:       synchronizerIDs,
+	}
+
+	_, err := c.client.SetNoWaitCommitmentsFrom(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cantonPruning) ResetNoWaitCommitmentsFrom(ctx context.Context, counterParticipantIDs []string, synchronizerIDs []string) error {
+	req := &pruningv30.ResetNoWaitCommitmentsFromRequest{
+		CounterParticipantIds: counterParticipantIDs,
+		SynchronizerIds:       synchronizerIDs,
+	}
+
+	_, err := c.client.ResetNoWaitCommitmentsFrom(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cantonPruning) GetNoWaitCommitmentsFrom(ctx context.Context, synchronizerIDs []string, participantUIDs []string) (*model.NoWaitCommitmentsConfig, error) {
+	req := &pruningv30.GetNoWaitCommitmentsFromRequest{
+		SynchronizerIds: synchronizerIDs,
+		ParticipantUids: participantUIDs,
+	}
+
+	resp, err := c.client.GetNoWaitCommitmentsFrom(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.NoWaitCommitmentsConfig{
+		IgnoredParticipants:    waitCommitmentsSetupsFromProto(resp.IgnoredParticipants),
+		NotIgnoredParticipants: waitCommitmentsSetupsFromProto(resp.NotIgnoredParticipants),
+	}, nil
+}
+
+func waitCommitmentsSetupsFromProto(pbs []*pruningv30.WaitCommitmentsSetup) []*model.WaitCommitmentsSetup {
+	result := make([]*model.WaitCommitmentsSetup, len(pbs))
+	for i, pb := range pbs {
+		setup := &model.WaitCommitmentsSetup{
+			CounterParticipantUID: pb.CounterParticipantUid,
+		}
+		if pb.Synchronizers != nil {
+			setup.SynchronizerIDs = pb.Synchronizers.SynchronizerIds
+		}
+		result[i] = setup
+	}
+	return result
+}
+
+func safeToPruneCommitmentStateToProto(state model.SafeToPruneCommitmentState) *participantv30.SafeToPruneCommitmentState {
+	var pb participantv30.SafeToPruneCommitmentState
+	switch state {
+	case model.SafeToPruneCommitmentStateMatch:
+		pb = participantv30.SafeToPruneCommitmentState_SAFE_TO_PRUNE_COMMITMENT_STATE_MATCH
+	case model.SafeToPruneCommitmentStateMatchMismatch:
+		pb = participantv30.SafeToPruneCommitmentState_SAFE_TO_PRUNE_COMMITMENT_STATE_MATCH_MISMATCH
+	case model.SafeToPruneCommitmentStateAll:
+		pb = participantv30.SafeToPruneCommitmentState_SAFE_TO_PRUNE_COMMITMENT_STATE_ALL
+	default:
+		return nil
+	}
+	return &pb
+}
@@ -0,0 +1,202 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	participantv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/participant/v30"
+)
+
+type DarManagement interface {
+	ListDars(ctx context.Context, req *model.ListDarsRequest) ([]*model.DarDescription, error)
+	GetDar(ctx context.Context, mainPackageID string) (*model.Dar, error)
+	GetDarContents(ctx context.Context, mainPackageID string) (*model.DarContents, error)
+	RemoveDar(ctx context.Context, mainPackageID string) error
+	RemovePackage(ctx context.Context, packageID string, force bool) error
+	VetDar(ctx context.Context, req *model.VetDarRequest) error
+	UnvetDar(ctx context.Context, req *model.UnvetDarRequest) error
+	GetPackageReferences(ctx context.Context, packageID string) ([]*model.DarDescription, error)
+}
+
+type darManagement struct {
+	client participantv30.PackageServiceClient
+}
+
+func NewDarManagementClient(conn *grpc.ClientConn) *darManagement {
+	client := participantv30.NewPackageServiceClient(conn)
+	return &darManagement{
+		client: client,
+	}
+}
+
+func (c *darManagement) ListDars(ctx context.Context, req *model.ListDarsRequest) ([]*model.DarDescription, error) {
+	protoReq := &participantv30.ListDarsRequest{}
+	if req != nil {
+		protoReq.Limit = req.Limit
+		protoReq.FilterName = req.FilterName
+	}
+
+	resp, err := c.client.ListDars(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return darDescriptionsFromProto(resp.Dars), nil
+}
+
+func (c *darManagement) GetDar(ctx context.Context, mainPackageID string) (*model.Dar, error) {
+	req := &participantv30.GetDarRequest{
+		MainPackageId: mainPackageID,
+	}
+
+	resp, err := c.client.GetDar(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Dar{
+		Payload:     resp.Payload,
+		Description: darDescriptionFromProto(resp.Data),
+	}, nil
+}
+
+func (c *darManagement) GetDarContents(ctx context.Context, mainPackageID string) (*model.DarContents, error) {
+	req := &participantv30.GetDarContentsRequest{
+		MainPackageId: mainPackageID,
+	}
+
+	resp, err := c.client.GetDarContents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]*model.PackageDescription, len(resp.Packages))
+	for i, pkg := range resp.Packages {
+		packages[i] = packageDescriptionFromProto(pkg)
+	}
+
+	return &model.DarContents{
+		Description: darDescriptionFromProto(resp.Description),
+		Packages:    packages,
+	}, nil
+}
+
+func (c *darManagement) RemoveDar(ctx context.Context, mainPackageID string) error {
+	req := &participantv30.RemoveDarRequest{
+		MainPackageId: mainPackageID,
+	}
+
+	_, err := c.client.RemoveDar(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *darManagement) RemovePackage(ctx context.Context, packageID string, force bool) error {
+	req := &participantv30.RemovePackageRequest{
+		PackageId: packageID,
+		Force:     force,
+	}
+
+	_, err := c.client.RemovePackage(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *darManagement) VetDar(ctx context.Context, req *model.VetDarRequest) error {
+	protoReq := &participantv30.VetDarRequest{
+		MainPackageId:  req.MainPackageID,
+		Synchronize:    req.Synchronize,
+		SynchronizerId: optionalString(req.SynchronizerID),
+	}
+
+	_, err := c.client.VetDar(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *darManagement) UnvetDar(ctx context.Context, req *model.UnvetDarRequest) error {
+	protoReq := &participantv30.UnvetDarRequest{
+		MainPackageId:  req.MainPackageID,
+		SynchronizerId: optionalString(req.SynchronizerID),
+	}
+
+	_, err := c.client.UnvetDar(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *darManagement) GetPackageReferences(ctx context.Context, packageID string) ([]*model.DarDescription, error) {
+	req := &participantv30.GetPackageReferencesRequest{
+		PackageId: packageID,
+	}
+
+	resp, err := c.client.GetPackageReferences(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return darDescriptionsFromProto(resp.Dars), nil
+}
+
+func darDescriptionFromProto(pb *participantv30.DarDescription) *model.DarDescription {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.DarDescription{
+		MainPackageID: pb.Main,
+		Name:          pb.Name,
+		Version:       pb.Version,
+		Description:   pb.Description,
+	}
+}
+
+func darDescriptionsFromProto(pbs []*participantv30.DarDescription) []*model.DarDescription {
+	result := make([]*model.DarDescription, len(pbs))
+	for i, pb := range pbs {
+		result[i] = darDescriptionFromProto(pb)
+	}
+	return result
+}
+
+func packageDescriptionFromProto(pb *participantv30.PackageDescription) *model.PackageDescription {
+	if pb == nil {
+		return nil
+	}
+
+	var uploadedAt *time.Time
+	if pb.UploadedAt != nil {
+		t := pb.UploadedAt.AsTime()
+		uploadedAt = &t
+	}
+
+	return &model.PackageDescription{
+		PackageID:  pb.PackageId,
+		Name:       pb.Name,
+		Version:    pb.Version,
+		UploadedAt: uploadedAt,
+		Size:       pb.Size,
+	}
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
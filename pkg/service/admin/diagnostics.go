@@ -0,0 +1,157 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/health"
+	healthv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/health/v30"
+	participantv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/participant/v30"
+)
+
+type Diagnostics interface {
+	ParticipantStatus(ctx context.Context) (*model.ParticipantStatus, error)
+	// HealthDump streams the zipped health dump of the Canton process into w.
+	// A chunkSize of zero leaves the chunk size to the server.
+	HealthDump(ctx context.Context, w io.Writer, chunkSize uint32) error
+	SetLogLevel(ctx context.Context, level string) error
+	Ping(ctx context.Context, req *model.PingRequest) (*model.PingResult, error)
+}
+
+type diagnostics struct {
+	statusClient            healthv30.StatusServiceClient
+	participantStatusClient participantv30.ParticipantStatusServiceClient
+	pingClient              participantv30.PingServiceClient
+}
+
+func NewDiagnosticsClient(conn *grpc.ClientConn) *diagnostics {
+	return &diagnostics{
+		statusClient:            healthv30.NewStatusServiceClient(conn),
+		participantStatusClient: participantv30.NewParticipantStatusServiceClient(conn),
+		pingClient:              participantv30.NewPingServiceClient(conn),
+	}
+}
+
+func (c *diagnostics) ParticipantStatus(ctx context.Context) (*model.ParticipantStatus, error) {
+	resp, err := c.participantStatusClient.ParticipantStatus(ctx, &participantv30.ParticipantStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind := resp.Kind.(type) {
+	case *participantv30.ParticipantStatusResponse_Status:
+		return participantStatusFromProto(kind.Status), nil
+	case *participantv30.ParticipantStatusResponse_NotInitialized:
+		return &model.ParticipantStatus{
+			Active:                  kind.NotInitialized.GetActive(),
+			WaitingForExternalInput: model.WaitingForExternalInput(kind.NotInitialized.GetWaitingForExternalInput()),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected participant status response: %T", resp.Kind)
+	}
+}
+
+func (c *diagnostics) HealthDump(ctx context.Context, w io.Writer, chunkSize uint32) error {
+	req := &healthv30.HealthDumpRequest{}
+	if chunkSize > 0 {
+		req.ChunkSize = &chunkSize
+	}
+
+	stream, err := c.statusClient.HealthDump(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(resp.Chunk); err != nil {
+			return fmt.Errorf("failed to write health dump chunk: %w", err)
+		}
+	}
+}
+
+func (c *diagnostics) SetLogLevel(ctx context.Context, level string) error {
+	req := &healthv30.SetLogLevelRequest{
+		Level: level,
+	}
+
+	_, err := c.statusClient.SetLogLevel(ctx, req)
+	return err
+}
+
+func (c *diagnostics) Ping(ctx context.Context, req *model.PingRequest) (*model.PingResult, error) {
+	protoReq := &participantv30.PingRequest{
+		TargetParties:  req.TargetParties,
+		Validators:     req.Validators,
+		Levels:         req.Levels,
+		SynchronizerId: req.SynchronizerID,
+		WorkflowId:     req.WorkflowID,
+		Id:             req.ID,
+	}
+	if req.Timeout > 0 {
+		protoReq.Timeout = durationpb.New(req.Timeout)
+	}
+
+	resp, err := c.pingClient.Ping(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := resp.Response.(type) {
+	case *participantv30.PingResponse_Success:
+		return &model.PingResult{
+			Success:   true,
+			RoundTrip: time.Duration(r.Success.GetPingTime()) * time.Millisecond,
+			Responder: r.Success.GetResponder(),
+		}, nil
+	case *participantv30.PingResponse_Failure:
+		return &model.PingResult{
+			Reason: r.Failure.GetReason(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected ping response: %T", resp.Response)
+	}
+}
+
+func participantStatusFromProto(pb *participantv30.ParticipantStatusResponse_ParticipantStatusResponseStatus) *model.ParticipantStatus {
+	if pb == nil {
+		return nil
+	}
+
+	status := &model.ParticipantStatus{
+		Initialized:               true,
+		Active:                    pb.Active,
+		SupportedProtocolVersions: pb.SupportedProtocolVersions,
+	}
+
+	if common := pb.CommonStatus; common != nil {
+		status.UID = common.Uid
+		status.Uptime = common.Uptime.AsDuration()
+		status.Ports = common.Ports
+		status.Version = common.Version
+		status.Components = health.ComponentStatusFromProtos(common.Components)
+	}
+
+	status.ConnectedSynchronizers = make([]*model.ParticipantSynchronizerHealth, len(pb.ConnectedSynchronizers))
+	for i, sync := range pb.ConnectedSynchronizers {
+		status.ConnectedSynchronizers[i] = &model.ParticipantSynchronizerHealth{
+			PhysicalSynchronizerID: sync.PhysicalSynchronizerId,
+			Health:                 model.SynchronizerHealth(sync.Health),
+		}
+	}
+
+	return status
+}
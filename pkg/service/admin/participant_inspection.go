@@ -0,0 +1,441 @@
+package admin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	participantv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/participant/v30"
+)
+
+type ParticipantInspection interface {
+	// LookupOffsetByTime returns the ledger offset corresponding to the given
+	// timestamp, or nil when no such offset exists.
+	LookupOffsetByTime(ctx context.Context, timestamp time.Time) (*int64, error)
+	OpenCommitment(ctx context.Context, req *model.OpenCommitmentRequest) ([]*model.CommitmentContractMeta, error)
+	InspectCommitmentContracts(ctx context.Context, req *model.InspectCommitmentContractsRequest) ([]*model.CommitmentContract, error)
+	LookupSentAcsCommitments(ctx context.Context, req *model.LookupSentAcsCommitmentsRequest) ([]*model.SentAcsCommitment, error)
+	LookupReceivedAcsCommitments(ctx context.Context, req *model.LookupReceivedAcsCommitmentsRequest) ([]*model.ReceivedAcsCommitment, error)
+	SetConfigForSlowCounterParticipants(ctx context.Context, configs []*model.SlowCounterParticipantConfig) error
+	GetConfigForSlowCounterParticipants(ctx context.Context, synchronizerIDs []string) ([]*model.SlowCounterParticipantConfig, error)
+	GetIntervalsBehindForCounterParticipants(ctx context.Context, req *model.GetIntervalsBehindRequest) ([]*model.CounterParticipantInfo, error)
+	CountInFlight(ctx context.Context, synchronizerID string) (*model.InFlightCount, error)
+}
+
+type participantInspection struct {
+	client participantv30.ParticipantInspectionServiceClient
+}
+
+func NewParticipantInspectionClient(conn *grpc.ClientConn) *participantInspection {
+	client := participantv30.NewParticipantInspectionServiceClient(conn)
+	return &participantInspection{
+		client: client,
+	}
+}
+
+func (c *participantInspection) LookupOffsetByTime(ctx context.Context, timestamp time.Time) (*int64, error) {
+	req := &participantv30.LookupOffsetByTimeRequest{
+		Timestamp: timestamppb.New(timestamp),
+	}
+
+	resp, err := c.client.LookupOffsetByTime(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Offset, nil
+}
+
+func (c *participantInspection) OpenCommitment(ctx context.Context, req *model.OpenCommitmentRequest) ([]*model.CommitmentContractMeta, error) {
+	protoReq := &participantv30.OpenCommitmentRequest{
+		Commitment:                       req.Commitment,
+		PhysicalSynchronizerId:           req.PhysicalSynchronizerID,
+		ComputedForCounterParticipantUid: req.ComputedForCounterParticipantUID,
+		PeriodEndTick:                    timestamppb.New(req.PeriodEndTick),
+	}
+
+	stream, err := c.client.OpenCommitment(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(resp.Chunk)
+	}
+
+	metas, err := readDelimited(buf.Bytes(), func() *participantv30.CommitmentContractMeta {
+		return &participantv30.CommitmentContractMeta{}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode commitment contracts: %w", err)
+	}
+
+	result := make([]*model.CommitmentContractMeta, len(metas))
+	for i, meta := range metas {
+		result[i] = &model.CommitmentContractMeta{
+			ContractID:          hex.EncodeToString(meta.Cid),
+			ReassignmentCounter: meta.ReassignmentCounter,
+		}
+	}
+
+	return result, nil
+}
+
+func (c *participantInspection) InspectCommitmentContracts(ctx context.Context, req *model.InspectCommitmentContractsRequest) ([]*model.CommitmentContract, error) {
+	cids := make([][]byte, len(req.ContractIDs))
+	for i, contractID := range req.ContractIDs {
+		cid, err := hex.DecodeString(contractID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract ID %q: %w", contractID, err)
+		}
+		cids[i] = cid
+	}
+
+	protoReq := &participantv30.InspectCommitmentContractsRequest{
+		Cids:                   cids,
+		ExpectedSynchronizerId: req.ExpectedSynchronizerID,
+		Timestamp:              timestamppb.New(req.Timestamp),
+		DownloadPayload:        req.DownloadPayload,
+	}
+
+	stream, err := c.client.InspectCommitmentContracts(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(resp.Chunk)
+	}
+
+	contracts, err := readDelimited(buf.Bytes(), func() *participantv30.CommitmentContract {
+		return &participantv30.CommitmentContract{}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode commitment contracts: %w", err)
+	}
+
+	result := make([]*model.CommitmentContract, len(contracts))
+	for i, contract := range contracts {
+		result[i] = commitmentContractFromProto(contract)
+	}
+
+	return result, nil
+}
+
+func (c *participantInspection) LookupSentAcsCommitments(ctx context.Context, req *model.LookupSentAcsCommitmentsRequest) ([]*model.SentAcsCommitment, error) {
+	states := make([]participantv30.SentCommitmentState, len(req.States))
+	for i, s := range req.States {
+		states[i] = participantv30.SentCommitmentState(s)
+	}
+
+	protoReq := &participantv30.LookupSentAcsCommitmentsRequest{
+		TimeRanges:            synchronizerTimeRangesToProto(req.TimeRanges),
+		CounterParticipantIds: req.CounterParticipantIDs,
+		CommitmentState:       states,
+		Verbose:               req.Verbose,
+	}
+
+	resp, err := c.client.LookupSentAcsCommitments(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*model.SentAcsCommitment
+	for _, perSync := range resp.Sent {
+		for _, sent := range perSync.Sent {
+			result = append(result, &model.SentAcsCommitment{
+				SynchronizerID:            perSync.SynchronizerId,
+				Interval:                  commitmentIntervalFromProto(sent.Interval),
+				DestCounterParticipantUID: sent.DestCounterParticipantUid,
+				OwnCommitment:             sent.OwnCommitment,
+				ReceivedCommitment:        sent.ReceivedCommitment,
+				State:                     model.SentCommitmentState(sent.State),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func (c *participantInspection) LookupReceivedAcsCommitments(ctx context.Context, req *model.LookupReceivedAcsCommitmentsRequest) ([]*model.ReceivedAcsCommitment, error) {
+	states := make([]participantv30.ReceivedCommitmentState, len(req.States))
+	for i, s := range req.States {
+		states[i] = participantv30.ReceivedCommitmentState(s)
+	}
+
+	protoReq := &participantv30.LookupReceivedAcsCommitmentsRequest{
+		TimeRanges:            synchronizerTimeRangesToProto(req.TimeRanges),
+		CounterParticipantIds: req.CounterParticipantIDs,
+		CommitmentState:       states,
+		Verbose:               req.Verbose,
+	}
+
+	resp, err := c.client.LookupReceivedAcsCommitments(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*model.ReceivedAcsCommitment
+	for _, perSync := range resp.Received {
+		for _, received := range perSync.Received {
+			result = append(result, &model.ReceivedAcsCommitment{
+				SynchronizerID:              perSync.SynchronizerId,
+				Interval:                    commitmentIntervalFromProto(received.Interval),
+				OriginCounterParticipantUID: received.OriginCounterParticipantUid,
+				ReceivedCommitment:          received.ReceivedCommitment,
+				OwnCommitment:               received.OwnCommitment,
+				State:                       model.ReceivedCommitmentState(received.State),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func (c *participantInspection) SetConfigForSlowCounterParticipants(ctx context.Context, configs []*model.SlowCounterParticipantConfig) error {
+	protoConfigs := make([]*participantv30.SlowCounterParticipantSynchronizerConfig, len(configs))
+	for i, cfg := range configs {
+		protoConfigs[i] = slowCounterParticipantConfigToProto(cfg)
+	}
+
+	req := &participantv30.SetConfigForSlowCounterParticipantsRequest{
+		Configs: protoConfigs,
+	}
+
+	_, err := c.client.SetConfigForSlowCounterParticipants(ctx, req)
+	return err
+}
+
+func (c *participantInspection) GetConfigForSlowCounterParticipants(ctx context.Context, synchronizerIDs []string) ([]*model.SlowCounterParticipantConfig, error) {
+	req := &participantv30.GetConfigForSlowCounterParticipantsRequest{
+		SynchronizerIds: synchronizerIDs,
+	}
+
+	resp, err := c.client.GetConfigForSlowCounterParticipants(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.SlowCounterParticipantConfig, len(resp.Configs))
+	for i, cfg := range resp.Configs {
+		result[i] = slowCounterParticipantConfigFromProto(cfg)
+	}
+
+	return result, nil
+}
+
+func (c *participantInspection) GetIntervalsBehindForCounterParticipants(ctx context.Context, req *model.GetIntervalsBehindRequest) ([]*model.CounterParticipantInfo, error) {
+	protoReq := &participantv30.GetIntervalsBehindForCounterParticipantsRequest{
+		CounterParticipantIds: req.CounterParticipantIDs,
+		SynchronizerIds:       req.SynchronizerIDs,
+		Threshold:             req.Threshold,
+	}
+
+	resp, err := c.client.GetIntervalsBehindForCounterParticipants(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.CounterParticipantInfo, len(resp.IntervalsBehind))
+	for i, info := range resp.IntervalsBehind {
+		result[i] = counterParticipantInfoFromProto(info)
+	}
+
+	return result, nil
+}
+
+func (c *participantInspection) CountInFlight(ctx context.Context, synchronizerID string) (*model.InFlightCount, error) {
+	req := &participantv30.CountInFlightRequest{
+		SynchronizerId: synchronizerID,
+	}
+
+	resp, err := c.client.CountInFlight(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.InFlightCount{
+		PendingSubmissions:  resp.PendingSubmissions,
+		PendingTransactions: resp.PendingTransactions,
+	}, nil
+}
+
+// readDelimited decodes a sequence of length-delimited messages, the format
+// Canton uses for the byte chunks of its inspection streams.
+func readDelimited[M proto.Message](data []byte, newMsg func() M) ([]M, error) {
+	r := bufio.NewReader(bytes.NewReader(data))
+
+	var result []M
+	for {
+		msg := newMsg()
+		err := protodelim.UnmarshalFrom(r, msg)
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, msg)
+	}
+}
+
+func synchronizerTimeRangesToProto(ranges []*model.SynchronizerTimeRange) []*participantv30.SynchronizerTimeRange {
+	result := make([]*participantv30.SynchronizerTimeRange, len(ranges))
+	for i, r := range ranges {
+		result[i] = &participantv30.SynchronizerTimeRange{
+			SynchronizerId: r.SynchronizerID,
+		}
+		if r.FromExclusive != nil || r.ToInclusive != nil {
+			interval := &participantv30.TimeRange{}
+			if r.FromExclusive != nil {
+				interval.FromExclusive = timestamppb.New(*r.FromExclusive)
+			}
+			if r.ToInclusive != nil {
+				interval.ToInclusive = timestamppb.New(*r.ToInclusive)
+			}
+			result[i].Interval = interval
+		}
+	}
+
+	return result
+}
+
+func commitmentIntervalFromProto(pb *participantv30.Interval) *model.CommitmentInterval {
+	if pb == nil {
+		return nil
+	}
+
+	interval := &model.CommitmentInterval{}
+	if pb.StartTickExclusive != nil {
+		t := pb.StartTickExclusive.AsTime()
+		interval.StartTickExclusive = &t
+	}
+	if pb.EndTickInclusive != nil {
+		t := pb.EndTickInclusive.AsTime()
+		interval.EndTickInclusive = &t
+	}
+
+	return interval
+}
+
+func commitmentContractFromProto(pb *participantv30.CommitmentContract) *model.CommitmentContract {
+	if pb == nil {
+		return nil
+	}
+
+	states := make([]*model.ContractSynchronizerState, len(pb.States))
+	for i, s := range pb.States {
+		states[i] = contractSynchronizerStateFromProto(s)
+	}
+
+	return &model.CommitmentContract{
+		ContractID:                   hex.EncodeToString(pb.Cid),
+		ActiveOnExpectedSynchronizer: pb.ActiveOnExpectedSynchronizer,
+		Contract:                     pb.Contract,
+		States:                       states,
+	}
+}
+
+func contractSynchronizerStateFromProto(pb *participantv30.ContractState_SynchronizerState) *model.ContractSynchronizerState {
+	if pb == nil {
+		return nil
+	}
+
+	state := &model.ContractSynchronizerState{
+		SynchronizerID: pb.SynchronizerId,
+	}
+
+	switch s := pb.State.(type) {
+	case *participantv30.ContractState_SynchronizerState_Created:
+		state.State = model.ContractStateKindCreated
+	case *participantv30.ContractState_SynchronizerState_Archived:
+		state.State = model.ContractStateKindArchived
+	case *participantv30.ContractState_SynchronizerState_Unassigned:
+		state.State = model.ContractStateKindUnassigned
+		state.TargetSynchronizerID = s.Unassigned.GetTargetSynchronizerId()
+		state.ReassignmentCounter = s.Unassigned.GetReassignmentCounterSrc()
+		state.ReassignmentID = s.Unassigned.GetReassignmentId().GetId()
+	case *participantv30.ContractState_SynchronizerState_Assigned:
+		state.State = model.ContractStateKindAssigned
+		state.ReassignmentCounter = s.Assigned.GetReassignmentCounterTarget()
+		state.ReassignmentID = s.Assigned.GetReassignmentId().GetId()
+	default:
+		state.State = model.ContractStateKindUnknown
+	}
+
+	return state
+}
+
+func slowCounterParticipantConfigToProto(cfg *model.SlowCounterParticipantConfig) *participantv30.SlowCounterParticipantSynchronizerConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	return &participantv30.SlowCounterParticipantSynchronizerConfig{
+		SynchronizerIds:              cfg.SynchronizerIDs,
+		DistinguishedParticipantUids: cfg.DistinguishedParticipantUIDs,
+		ThresholdDistinguished:       cfg.ThresholdDistinguished,
+		ThresholdDefault:             cfg.ThresholdDefault,
+		ParticipantUidsMetrics:       cfg.ParticipantUIDsMetrics,
+	}
+}
+
+func slowCounterParticipantConfigFromProto(pb *participantv30.SlowCounterParticipantSynchronizerConfig) *model.SlowCounterParticipantConfig {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.SlowCounterParticipantConfig{
+		SynchronizerIDs:              pb.SynchronizerIds,
+		DistinguishedParticipantUIDs: pb.DistinguishedParticipantUids,
+		ThresholdDistinguished:       pb.ThresholdDistinguished,
+		ThresholdDefault:             pb.ThresholdDefault,
+		ParticipantUIDsMetrics:       pb.ParticipantUidsMetrics,
+	}
+}
+
+func counterParticipantInfoFromProto(pb *participantv30.CounterParticipantInfo) *model.CounterParticipantInfo {
+	if pb == nil {
+		return nil
+	}
+
+	info := &model.CounterParticipantInfo{
+		CounterParticipantUID: pb.CounterParticipantUid,
+		SynchronizerID:        pb.SynchronizerId,
+		IntervalsBehind:       pb.IntervalsBehind,
+		BehindSince:           durationFromProto(pb.BehindSince),
+	}
+	if pb.AsOfSequencingTimestamp != nil {
+		t := pb.AsOfSequencingTimestamp.AsTime()
+		info.AsOfSequencingTimestamp = &t
+	}
+
+	return info
+}
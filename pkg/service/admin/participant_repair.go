@@ -0,0 +1,300 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	participantv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/participant/v30"
+)
+
+const defaultAcsImportChunkSize = 1 << 20
+
+// ErrRepairNotConfirmed is returned by destructive repair operations whose
+// request does not set Confirm.
+var ErrRepairNotConfirmed = errors.New("destructive repair operation requires Confirm to be set")
+
+type ParticipantRepair interface {
+	ExportAcs(ctx context.Context, req *model.ExportAcsRequest, w io.Writer) error
+	ImportAcs(ctx context.Context, req *model.ImportAcsRequest, r io.Reader) error
+	PurgeContracts(ctx context.Context, req *model.PurgeContractsRequest) error
+	MigrateSynchronizer(ctx context.Context, req *model.MigrateSynchronizerRequest) error
+	ChangeAssignation(ctx context.Context, req *model.ChangeAssignationRequest) error
+	PurgeDeactivatedSynchronizer(ctx context.Context, req *model.PurgeDeactivatedSynchronizerRequest) error
+	IgnoreEvents(ctx context.Context, req *model.IgnoreEventsRequest) error
+	UnignoreEvents(ctx context.Context, req *model.UnignoreEventsRequest) error
+	RollbackUnassignment(ctx context.Context, req *model.RollbackUnassignmentRequest) error
+}
+
+type participantRepair struct {
+	client participantv30.ParticipantRepairServiceClient
+}
+
+func NewParticipantRepairClient(conn *grpc.ClientConn) *participantRepair {
+	client := participantv30.NewParticipantRepairServiceClient(conn)
+	return &participantRepair{
+		client: client,
+	}
+}
+
+func (c *participantRepair) ExportAcs(ctx context.Context, req *model.ExportAcsRequest, w io.Writer) error {
+	protoReq := &participantv30.ExportAcsRequest{
+		PartyIds:               req.PartyIDs,
+		SynchronizerId:         req.SynchronizerID,
+		LedgerOffset:           req.LedgerOffset,
+		ExcludedStakeholderIds: req.ExcludedStakeholderIDs,
+	}
+
+	if len(req.ContractSynchronizerRenames) > 0 {
+		renames := make(map[string]*participantv30.ExportAcsTargetSynchronizer, len(req.ContractSynchronizerRenames))
+		for source, target := range req.ContractSynchronizerRenames {
+			renames[source] = &participantv30.ExportAcsTargetSynchronizer{
+				TargetSynchronizerId: target,
+			}
+		}
+		protoReq.ContractSynchronizerRenames = renames
+	}
+
+	stream, err := c.client.ExportAcs(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(resp.Chunk); err != nil {
+			return fmt.Errorf("failed to write ACS export chunk: %w", err)
+		}
+	}
+}
+
+func (c *participantRepair) ImportAcs(ctx context.Context, req *model.ImportAcsRequest, r io.Reader) error {
+	chunkSize := req.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultAcsImportChunkSize
+	}
+
+	stream, err := c.client.ImportAcs(ctx)
+	if err != nil {
+		return err
+	}
+
+	first := true
+	buf := make([]byte, chunkSize)
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			_ = stream.CloseSend()
+			return fmt.Errorf("failed to read ACS snapshot: %w", readErr)
+		}
+
+		if n > 0 || first {
+			chunk := &participantv30.ImportAcsRequest{
+				AcsSnapshot: append([]byte(nil), buf[:n]...),
+			}
+			if first {
+				importAcsRequestOptionsToProto(req, chunk)
+				first = false
+			}
+
+			if err := stream.Send(chunk); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return err
+			}
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *participantRepair) PurgeContracts(ctx context.Context, req *model.PurgeContractsRequest) error {
+	if !req.Confirm {
+		return fmt.Errorf("purge contracts: %w", ErrRepairNotConfirmed)
+	}
+
+	protoReq := &participantv30.PurgeContractsRequest{
+		SynchronizerAlias:   req.SynchronizerAlias,
+		ContractIds:         req.ContractIDs,
+		IgnoreAlreadyPurged: req.IgnoreAlreadyPurged,
+	}
+
+	_, err := c.client.PurgeContracts(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *participantRepair) MigrateSynchronizer(ctx context.Context, req *model.MigrateSynchronizerRequest) error {
+	if !req.Confirm {
+		return fmt.Errorf("migrate synchronizer: %w", ErrRepairNotConfirmed)
+	}
+
+	protoReq := &participantv30.MigrateSynchronizerRequest{
+		SourceSynchronizerAlias:            req.SourceSynchronizerAlias,
+		TargetSynchronizerConnectionConfig: synchronizerConnectionConfigToProto(req.TargetConfig),
+		Force:                              req.Force,
+	}
+
+	_, err := c.client.MigrateSynchronizer(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *participantRepair) ChangeAssignation(ctx context.Context, req *model.ChangeAssignationRequest) error {
+	if !req.Confirm {
+		return fmt.Errorf("change assignation: %w", ErrRepairNotConfirmed)
+	}
+
+	contracts := make([]*participantv30.ChangeAssignationRequest_Contract, len(req.Contracts))
+	for i, contract := range req.Contracts {
+		contracts[i] = &participantv30.ChangeAssignationRequest_Contract{
+			Id:                          contract.ContractID,
+			ReassignmentCounterOverride: contract.ReassignmentCounterOverride,
+		}
+	}
+
+	protoReq := &participantv30.ChangeAssignationRequest{
+		SourceSynchronizerAlias: req.SourceSynchronizerAlias,
+		TargetSynchronizerAlias: req.TargetSynchronizerAlias,
+		SkipInactive:            req.SkipInactive,
+		Contracts:               contracts,
+	}
+
+	_, err := c.client.ChangeAssignation(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *participantRepair) PurgeDeactivatedSynchronizer(ctx context.Context, req *model.PurgeDeactivatedSynchronizerRequest) error {
+	if !req.Confirm {
+		return fmt.Errorf("purge deactivated synchronizer: %w", ErrRepairNotConfirmed)
+	}
+
+	protoReq := &participantv30.PurgeDeactivatedSynchronizerRequest{
+		SynchronizerAlias: req.SynchronizerAlias,
+	}
+
+	_, err := c.client.PurgeDeactivatedSynchronizer(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *participantRepair) IgnoreEvents(ctx context.Context, req *model.IgnoreEventsRequest) error {
+	if !req.Confirm {
+		return fmt.Errorf("ignore events: %w", ErrRepairNotConfirmed)
+	}
+
+	protoReq := &participantv30.IgnoreEventsRequest{
+		PhysicalSynchronizerId: req.PhysicalSynchronizerID,
+		FromInclusive:          req.FromInclusive,
+		ToInclusive:            req.ToInclusive,
+		Force:                  req.Force,
+	}
+
+	_, err := c.client.IgnoreEvents(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *participantRepair) UnignoreEvents(ctx context.Context, req *model.UnignoreEventsRequest) error {
+	if !req.Confirm {
+		return fmt.Errorf("unignore events: %w", ErrRepairNotConfirmed)
+	}
+
+	protoReq := &participantv30.UnignoreEventsRequest{
+		PhysicalSynchronizerId: req.PhysicalSynchronizerID,
+		FromInclusive:          req.FromInclusive,
+		ToInclusive:            req.ToInclusive,
+		Force:                  req.Force,
+	}
+
+	_, err := c.client.UnignoreEvents(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *participantRepair) RollbackUnassignment(ctx context.Context, req *model.RollbackUnassignmentRequest) error {
+	if !req.Confirm {
+		return fmt.Errorf("rollback unassignment: %w", ErrRepairNotConfirmed)
+	}
+
+	protoReq := &participantv30.RollbackUnassignmentRequest{
+		ReassignmentId:       req.ReassignmentID,
+		SourceSynchronizerId: req.SourceSynchronizerID,
+		TargetSynchronizerId: req.TargetSynchronizerID,
+	}
+
+	_, err := c.client.RollbackUnassignment(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func importAcsRequestOptionsToProto(req *model.ImportAcsRequest, pb *participantv30.ImportAcsRequest) {
+	pb.SynchronizerId = optionalString(req.SynchronizerID)
+	pb.WorkflowIdPrefix = optionalString(req.WorkflowIDPrefix)
+	pb.ExcludedStakeholderIds = req.ExcludedStakeholderIDs
+
+	mode := contractImportModeToProto(req.ContractImportMode)
+	pb.ContractImportMode = &mode
+
+	if req.RepresentativePackageIDOverride != nil {
+		pb.RepresentativePackageIdOverride = &participantv30.RepresentativePackageIdOverride{
+			ContractOverride:    req.RepresentativePackageIDOverride.ContractOverride,
+			PackageIdOverride:   req.RepresentativePackageIDOverride.PackageIDOverride,
+			PackageNameOverride: req.RepresentativePackageIDOverride.PackageNameOverride,
+		}
+	}
+}
+
+func contractImportModeToProto(mode model.ContractImportMode) participantv30.ContractImportMode {
+	switch mode {
+	case model.ContractImportModeAccept:
+		return participantv30.ContractImportMode_CONTRACT_IMPORT_MODE_ACCEPT
+	case model.ContractImportModeValidation:
+		return participantv30.ContractImportMode_CONTRACT_IMPORT_MODE_VALIDATION
+	default:
+		return participantv30.ContractImportMode_CONTRACT_IMPORT_MODE_UNSPECIFIED
+	}
+}
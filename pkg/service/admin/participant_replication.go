@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	participantv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/participant/v30"
+)
+
+type ParticipantReplication interface {
+	// SetPassive makes an active participant replica passive so that another
+	// replica can take over.
+	SetPassive(ctx context.Context) error
+}
+
+type participantReplication struct {
+	client participantv30.ParticipantReplicationServiceClient
+}
+
+func NewParticipantReplicationClient(conn *grpc.ClientConn) *participantReplication {
+	client := participantv30.NewParticipantReplicationServiceClient(conn)
+	return &participantReplication{
+		client: client,
+	}
+}
+
+func (c *participantReplication) SetPassive(ctx context.Context) error {
+	_, err := c.client.SetPassive(ctx, &participantv30.SetPassiveRequest{})
+	return err
+}
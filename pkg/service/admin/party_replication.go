@@ -0,0 +1,195 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	participantv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/participant/v30"
+)
+
+// PartyReplication wraps the Canton participant admin party management service
+// used to replicate an existing party onto additional participants.
+type PartyReplication interface {
+	// AddPartyAsync starts replicating a party onto this participant and returns
+	// the request ID, which is stable across retries with the same arguments.
+	AddPartyAsync(ctx context.Context, args *model.AddPartyArguments) (string, error)
+	GetAddPartyStatus(ctx context.Context, requestID string) (*model.PartyReplicationStatus, error)
+	ExportPartyAcs(ctx context.Context, req *model.ExportPartyAcsRequest, w io.Writer) error
+	GetHighestOffsetByTimestamp(ctx context.Context, synchronizerID string, timestamp time.Time, force bool) (int64, error)
+	ClearPartyOnboardingFlag(ctx context.Context, req *model.ClearPartyOnboardingFlagRequest) (*model.ClearPartyOnboardingFlagResult, error)
+}
+
+type partyReplication struct {
+	client participantv30.PartyManagementServiceClient
+}
+
+func NewPartyReplicationClient(conn *grpc.ClientConn) *partyReplication {
+	client := participantv30.NewPartyManagementServiceClient(conn)
+	return &partyReplication{
+		client: client,
+	}
+}
+
+func (c *partyReplication) AddPartyAsync(ctx context.Context, args *model.AddPartyArguments) (string, error) {
+	req := &participantv30.AddPartyAsyncRequest{
+		Arguments: addPartyArgumentsToProto(args),
+	}
+
+	resp, err := c.client.AddPartyAsync(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.AddPartyRequestId, nil
+}
+
+func (c *partyReplication) GetAddPartyStatus(ctx context.Context, requestID string) (*model.PartyReplicationStatus, error) {
+	req := &participantv30.GetAddPartyStatusRequest{
+		AddPartyRequestId: requestID,
+	}
+
+	resp, err := c.client.GetAddPartyStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return partyReplicationStatusFromProto(resp.Status), nil
+}
+
+func (c *partyReplication) ExportPartyAcs(ctx context.Context, req *model.ExportPartyAcsRequest, w io.Writer) error {
+	protoReq := &participantv30.ExportPartyAcsRequest{
+		PartyId:                  req.PartyID,
+		SynchronizerId:           req.SynchronizerID,
+		TargetParticipantUid:     req.TargetParticipantUID,
+		BeginOffsetExclusive:     req.BeginOffsetExclusive,
+		WaitForActivationTimeout: durationToProto(req.WaitForActivationTimeout),
+	}
+
+	stream, err := c.client.ExportPartyAcs(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(resp.Chunk); err != nil {
+			return fmt.Errorf("failed to write party ACS chunk: %w", err)
+		}
+	}
+}
+
+func (c *partyReplication) GetHighestOffsetByTimestamp(ctx context.Context, synchronizerID string, timestamp time.Time, force bool) (int64, error) {
+	req := &participantv30.GetHighestOffsetByTimestampRequest{
+		SynchronizerId: synchronizerID,
+		Timestamp:      timestamppb.New(timestamp),
+		Force:          force,
+	}
+
+	resp, err := c.client.GetHighestOffsetByTimestamp(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.LedgerOffset, nil
+}
+
+func (c *partyReplication) ClearPartyOnboardingFlag(ctx context.Context, req *model.ClearPartyOnboardingFlagRequest) (*model.ClearPartyOnboardingFlagResult, error) {
+	protoReq := &participantv30.ClearPartyOnboardingFlagRequest{
+		PartyId:                  req.PartyID,
+		SynchronizerId:           req.SynchronizerID,
+		BeginOffsetExclusive:     req.BeginOffsetExclusive,
+		WaitForActivationTimeout: durationToProto(req.WaitForActivationTimeout),
+	}
+
+	resp, err := c.client.ClearPartyOnboardingFlag(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ClearPartyOnboardingFlagResult{
+		Onboarded: resp.Onboarded,
+	}
+	if resp.EarliestRetryTimestamp != nil {
+		t := resp.EarliestRetryTimestamp.AsTime()
+		result.EarliestRetry = &t
+	}
+
+	return result, nil
+}
+
+func addPartyArgumentsToProto(args *model.AddPartyArguments) *participantv30.AddPartyArguments {
+	if args == nil {
+		return nil
+	}
+
+	return &participantv30.AddPartyArguments{
+		PartyId:               args.PartyID,
+		SynchronizerId:        args.SynchronizerID,
+		SourceParticipantUid:  args.SourceParticipantUID,
+		TopologySerial:        args.TopologySerial,
+		ParticipantPermission: participantPermissionToProto(args.ParticipantPermission),
+	}
+}
+
+func participantPermissionToProto(permission model.ParticipantPermission) participantv30.ParticipantPermission {
+	switch permission {
+	case model.ParticipantPermissionConfirmation:
+		return participantv30.ParticipantPermission_PARTICIPANT_PERMISSION_CONFIRMATION
+	case model.ParticipantPermissionObservation:
+		return participantv30.ParticipantPermission_PARTICIPANT_PERMISSION_OBSERVATION
+	default:
+		return participantv30.ParticipantPermission_PARTICIPANT_PERMISSION_SUBMISSION
+	}
+}
+
+func partyReplicationStatusFromProto(pb *participantv30.PartyReplicationStatus) *model.PartyReplicationStatus {
+	if pb == nil {
+		return nil
+	}
+
+	status := &model.PartyReplicationStatus{
+		Completed: pb.HasCompleted,
+		Indexing:  pb.Indexing != nil,
+	}
+
+	if params := pb.Parameters; params != nil {
+		status.RequestID = params.RequestId
+		status.PartyID = params.PartyId
+		status.SynchronizerID = params.SynchronizerId
+		status.SourceParticipantUID = params.SourceParticipantUid
+		status.TargetParticipantUID = params.TargetParticipantUid
+		status.TopologySerial = params.TopologySerial
+	}
+	if pb.Agreement != nil {
+		status.SequencerUID = pb.Agreement.SequencerUid
+	}
+	if auth := pb.Authorization; auth != nil {
+		if auth.OnboardingAt != nil {
+			t := auth.OnboardingAt.AsTime()
+			status.OnboardingAt = &t
+		}
+		status.OnboardingFlagCleared = auth.IsOnboardingFlagCleared
+	}
+	if pb.Replication != nil {
+		status.ProcessedContractCount = pb.Replication.ProcessedContractCount
+		status.FullyProcessedAcs = pb.Replication.FullyProcessedAcs
+	}
+	if pb.ErrorMessage != nil {
+		status.Error = pb.ErrorMessage.ErrorMessage
+	}
+
+	return status
+}
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	participantv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/participant/v30"
+)
+
+type ResourceManagement interface {
+	SetResourceLimits(ctx context.Context, limits *model.ResourceLimits) error
+	GetResourceLimits(ctx context.Context) (*model.ResourceLimits, error)
+}
+
+type resourceManagement struct {
+	client participantv30.ResourceManagementServiceClient
+}
+
+func NewResourceManagementClient(conn *grpc.ClientConn) *resourceManagement {
+	client := participantv30.NewResourceManagementServiceClient(conn)
+	return &resourceManagement{
+		client: client,
+	}
+}
+
+func (c *resourceManagement) SetResourceLimits(ctx context.Context, limits *model.ResourceLimits) error {
+	req := &participantv30.SetResourceLimitsRequest{
+		NewLimits: resourceLimitsToProto(limits),
+	}
+
+	_, err := c.client.SetResourceLimits(ctx, req)
+	return err
+}
+
+func (c *resourceManagement) GetResourceLimits(ctx context.Context) (*model.ResourceLimits, error) {
+	resp, err := c.client.GetResourceLimits(ctx, &participantv30.GetResourceLimitsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceLimitsFromProto(resp.CurrentLimits), nil
+}
+
+func resourceLimitsToProto(limits *model.ResourceLimits) *participantv30.ResourceLimits {
+	if limits == nil {
+		return nil
+	}
+
+	return &participantv30.ResourceLimits{
+		MaxInflightValidationRequests: limits.MaxInflightValidationRequests,
+		MaxSubmissionRate:             limits.MaxSubmissionRate,
+		MaxSubmissionBurstFactor:      limits.MaxSubmissionBurstFactor,
+	}
+}
+
+func resourceLimitsFromProto(pb *participantv30.ResourceLimits) *model.ResourceLimits {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.ResourceLimits{
+		MaxInflightValidationRequests: pb.MaxInflightValidationRequests,
+		MaxSubmissionRate:             pb.MaxSubmissionRate,
+		MaxSubmissionBurstFactor:      pb.MaxSubmissionBurstFactor,
+	}
+}
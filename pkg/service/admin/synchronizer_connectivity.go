@@ -0,0 +1,365 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	participantv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/participant/v30"
+	sequencerv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/sequencer/v30"
+)
+
+type SynchronizerConnectivity interface {
+	RegisterSynchronizer(ctx context.Context, req *model.RegisterSynchronizerRequest) error
+	ConnectSynchronizer(ctx context.Context, req *model.ConnectSynchronizerRequest) (bool, error)
+	ReconnectSynchronizer(ctx context.Context, synchronizerAlias string, retry bool) (bool, error)
+	ReconnectSynchronizers(ctx context.Context, ignoreFailures bool) error
+	ModifySynchronizer(ctx context.Context, req *model.ModifySynchronizerRequest) error
+	DisconnectSynchronizer(ctx context.Context, synchronizerAlias string) error
+	DisconnectAllSynchronizers(ctx context.Context) error
+	ListConnectedSynchronizers(ctx context.Context) ([]*model.ConnectedSynchronizerStatus, error)
+	ListRegisteredSynchronizers(ctx context.Context) ([]*model.RegisteredSynchronizer, error)
+	GetSynchronizerID(ctx context.Context, synchronizerAlias string) (*model.GetSynchronizerIDResponse, error)
+	Logout(ctx context.Context, synchronizerAlias string) error
+}
+
+type synchronizerConnectivity struct {
+	client participantv30.SynchronizerConnectivityServiceClient
+}
+
+func NewSynchronizerConnectivityClient(conn *grpc.ClientConn) *synchronizerConnectivity {
+	client := participantv30.NewSynchronizerConnectivityServiceClient(conn)
+	return &synchronizerConnectivity{
+		client: client,
+	}
+}
+
+func (c *synchronizerConnectivity) RegisterSynchronizer(ctx context.Context, req *model.RegisterSynchronizerRequest) error {
+	protoReq := &participantv30.RegisterSynchronizerRequest{
+		Config:                        synchronizerConnectionConfigToProto(req.Config),
+		SynchronizerConnection:        synchronizerConnectionModeToProto(req.ConnectionMode),
+		SequencerConnectionValidation: sequencerConnectionValidationToProto(req.SequencerConnectionValidation),
+	}
+
+	_, err := c.client.RegisterSynchronizer(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *synchronizerConnectivity) ConnectSynchronizer(ctx context.Context, req *model.ConnectSynchronizerRequest) (bool, error) {
+	protoReq := &participantv30.ConnectSynchronizerRequest{
+		Config:                        synchronizerConnectionConfigToProto(req.Config),
+		SequencerConnectionValidation: sequencerConnectionValidationToProto(req.SequencerConnectionValidation),
+	}
+
+	resp, err := c.client.ConnectSynchronizer(ctx, protoReq)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.ConnectedSuccessfully, nil
+}
+
+func (c *synchronizerConnectivity) ReconnectSynchronizer(ctx context.Context, synchronizerAlias string, retry bool) (bool, error) {
+	req := &participantv30.ReconnectSynchronizerRequest{
+		SynchronizerAlias: synchronizerAlias,
+		Retry:             retry,
+	}
+
+	resp, err := c.client.ReconnectSynchronizer(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.ConnectedSuccessfully, nil
+}
+
+func (c *synchronizerConnectivity) ReconnectSynchronizers(ctx context.Context, ignoreFailures bool) error {
+	req := &participantv30.ReconnectSynchronizersRequest{
+		IgnoreFailures: ignoreFailures,
+	}
+
+	_, err := c.client.ReconnectSynchronizers(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *synchronizerConnectivity) ModifySynchronizer(ctx context.Context, req *model.ModifySynchronizerRequest) error {
+	protoReq := &participantv30.ModifySynchronizerRequest{
+		PhysicalSynchronizerId:        optionalString(req.PhysicalSynchronizerID),
+		NewConfig:                     synchronizerConnectionConfigToProto(req.NewConfig),
+		SequencerConnectionValidation: sequencerConnectionValidationToProto(req.SequencerConnectionValidation),
+	}
+
+	_, err := c.client.ModifySynchronizer(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *synchronizerConnectivity) DisconnectSynchronizer(ctx context.Context, synchronizerAlias string) error {
+	req := &participantv30.DisconnectSynchronizerRequest{
+		SynchronizerAlias: synchronizerAlias,
+	}
+
+	_, err := c.client.DisconnectSynchronizer(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *synchronizerConnectivity) DisconnectAllSynchronizers(ctx context.Context) error {
+	_, err := c.client.DisconnectAllSynchronizers(ctx, &participantv30.DisconnectAllSynchronizersRequest{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *synchronizerConnectivity) ListConnectedSynchronizers(ctx context.Context) ([]*model.ConnectedSynchronizerStatus, error) {
+	resp, err := c.client.ListConnectedSynchronizers(ctx, &participantv30.ListConnectedSynchronizersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ConnectedSynchronizerStatus, len(resp.ConnectedSynchronizers))
+	for i, s := range resp.ConnectedSynchronizers {
+		result[i] = &model.ConnectedSynchronizerStatus{
+			SynchronizerAlias:      s.SynchronizerAlias,
+			SynchronizerID:         s.SynchronizerId,
+			PhysicalSynchronizerID: s.PhysicalSynchronizerId,
+			Healthy:                s.Healthy,
+		}
+	}
+
+	return result, nil
+}
+
+func (c *synchronizerConnectivity) ListRegisteredSynchronizers(ctx context.Context) ([]*model.RegisteredSynchronizer, error) {
+	resp, err := c.client.ListRegisteredSynchronizers(ctx, &participantv30.ListRegisteredSynchronizersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.RegisteredSynchronizer, len(resp.Results))
+	for i, r := range resp.Results {
+		result[i] = &model.RegisteredSynchronizer{
+			Config:                 synchronizerConnectionConfigFromProto(r.Config),
+			Connected:              r.Connected,
+			PhysicalSynchronizerID: r.GetPhysicalSynchronizerId(),
+		}
+	}
+
+	return result, nil
+}
+
+func (c *synchronizerConnectivity) GetSynchronizerID(ctx context.Context, synchronizerAlias string) (*model.GetSynchronizerIDResponse, error) {
+	req := &participantv30.GetSynchronizerIdRequest{
+		SynchronizerAlias: synchronizerAlias,
+	}
+
+	resp, err := c.client.GetSynchronizerId(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GetSynchronizerIDResponse{
+		SynchronizerID:         resp.SynchronizerId,
+		PhysicalSynchronizerID: resp.PhysicalSynchronizerId,
+	}, nil
+}
+
+func (c *synchronizerConnectivity) Logout(ctx context.Context, synchronizerAlias string) error {
+	req := &participantv30.LogoutRequest{
+		SynchronizerAlias: synchronizerAlias,
+	}
+
+	_, err := c.client.Logout(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func synchronizerConnectionConfigToProto(cfg *model.SynchronizerConnectionConfig) *participantv30.SynchronizerConnectionConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	return &participantv30.SynchronizerConnectionConfig{
+		SynchronizerAlias:                 cfg.SynchronizerAlias,
+		SequencerConnections:              sequencerConnectionsToProto(cfg.SequencerConnections),
+		ManualConnect:                     cfg.ManualConnect,
+		PhysicalSynchronizerId:            cfg.PhysicalSynchronizerID,
+		Priority:                          cfg.Priority,
+		InitialRetryDelay:                 durationToProto(cfg.InitialRetryDelay),
+		MaxRetryDelay:                     durationToProto(cfg.MaxRetryDelay),
+		InitializeFromTrustedSynchronizer: cfg.InitializeFromTrustedSynchronizer,
+	}
+}
+
+func synchronizerConnectionConfigFromProto(pb *participantv30.SynchronizerConnectionConfig) *model.SynchronizerConnectionConfig {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.SynchronizerConnectionConfig{
+		SynchronizerAlias:                 pb.SynchronizerAlias,
+		SequencerConnections:              sequencerConnectionsFromProto(pb.SequencerConnections),
+		ManualConnect:                     pb.ManualConnect,
+		PhysicalSynchronizerID:            pb.PhysicalSynchronizerId,
+		Priority:                          pb.Priority,
+		InitialRetryDelay:                 durationFromProto(pb.InitialRetryDelay),
+		MaxRetryDelay:                     durationFromProto(pb.MaxRetryDelay),
+		InitializeFromTrustedSynchronizer: pb.InitializeFromTrustedSynchronizer,
+	}
+}
+
+func sequencerConnectionsToProto(conns *model.SequencerConnections) *sequencerv30.SequencerConnections {
+	if conns == nil {
+		return nil
+	}
+
+	pbConns := make([]*sequencerv30.SequencerConnection, len(conns.Connections))
+	for i, conn := range conns.Connections {
+		pbConns[i] = sequencerConnectionToProto(conn)
+	}
+
+	pb := &sequencerv30.SequencerConnections{
+		SequencerConnections:    pbConns,
+		SequencerTrustThreshold: conns.SequencerTrustThreshold,
+		SequencerLivenessMargin: conns.SequencerLivenessMargin,
+	}
+
+	if conns.SubmissionRequestAmplification != nil {
+		pb.SubmissionRequestAmplification = &sequencerv30.SubmissionRequestAmplification{
+			Factor:   conns.SubmissionRequestAmplification.Factor,
+			Patience: durationToProto(conns.SubmissionRequestAmplification.Patience),
+		}
+	}
+
+	return pb
+}
+
+func sequencerConnectionToProto(conn *model.SequencerConnection) *sequencerv30.SequencerConnection {
+	if conn == nil {
+		return nil
+	}
+
+	grpcConn := &sequencerv30.SequencerConnection_Grpc{
+		Connections:       conn.Endpoints,
+		TransportSecurity: conn.TransportSecurity,
+	}
+	if len(conn.CustomTrustCertificates) > 0 {
+		grpcConn.CustomTrustCertificates = conn.CustomTrustCertificates
+	}
+
+	return &sequencerv30.SequencerConnection{
+		Type: &sequencerv30.SequencerConnection_Grpc_{
+			Grpc: grpcConn,
+		},
+		Alias:       conn.Alias,
+		SequencerId: optionalString(conn.SequencerID),
+	}
+}
+
+func sequencerConnectionsFromProto(pb *sequencerv30.SequencerConnections) *model.SequencerConnections {
+	if pb == nil {
+		return nil
+	}
+
+	conns := make([]*model.SequencerConnection, len(pb.SequencerConnections))
+	for i, c := range pb.SequencerConnections {
+		conns[i] = sequencerConnectionFromProto(c)
+	}
+
+	result := &model.SequencerConnections{
+		Connections:             conns,
+		SequencerTrustThreshold: pb.SequencerTrustThreshold,
+		SequencerLivenessMargin: pb.SequencerLivenessMargin,
+	}
+
+	if pb.SubmissionRequestAmplification != nil {
+		result.SubmissionRequestAmplification = &model.SubmissionRequestAmplification{
+			Factor:   pb.SubmissionRequestAmplification.Factor,
+			Patience: durationFromProto(pb.SubmissionRequestAmplification.Patience),
+		}
+	}
+
+	return result
+}
+
+func sequencerConnectionFromProto(pb *sequencerv30.SequencerConnection) *model.SequencerConnection {
+	if pb == nil {
+		return nil
+	}
+
+	conn := &model.SequencerConnection{
+		Alias:       pb.Alias,
+		SequencerID: pb.GetSequencerId(),
+	}
+
+	if grpcConn := pb.GetGrpc(); grpcConn != nil {
+		conn.Endpoints = grpcConn.Connections
+		conn.TransportSecurity = grpcConn.TransportSecurity
+		conn.CustomTrustCertificates = grpcConn.CustomTrustCertificates
+	}
+
+	return conn
+}
+
+func sequencerConnectionValidationToProto(v model.SequencerConnectionValidation) sequencerv30.SequencerConnectionValidation {
+	switch v {
+	case model.SequencerConnectionValidationDisabled:
+		return sequencerv30.SequencerConnectionValidation_SEQUENCER_CONNECTION_VALIDATION_DISABLED
+	case model.SequencerConnectionValidationActive:
+		return sequencerv30.SequencerConnectionValidation_SEQUENCER_CONNECTION_VALIDATION_ACTIVE
+	case model.SequencerConnectionValidationAll:
+		return sequencerv30.SequencerConnectionValidation_SEQUENCER_CONNECTION_VALIDATION_ALL
+	case model.SequencerConnectionValidationThresholdActive:
+		return sequencerv30.SequencerConnectionValidation_SEQUENCER_CONNECTION_VALIDATION_THRESHOLD_ACTIVE
+	default:
+		return sequencerv30.SequencerConnectionValidation_SEQUENCER_CONNECTION_VALIDATION_UNSPECIFIED
+	}
+}
+
+func synchronizerConnectionModeToProto(mode model.SynchronizerConnectionMode) participantv30.RegisterSynchronizerRequest_SynchronizerConnection {
+	switch mode {
+	case model.SynchronizerConnectionModeNone:
+		return participantv30.RegisterSynchronizerRequest_SYNCHRONIZER_CONNECTION_NONE
+	case model.SynchronizerConnectionModeHandshake:
+		return participantv30.RegisterSynchronizerRequest_SYNCHRONIZER_CONNECTION_HANDSHAKE
+	default:
+		return participantv30.RegisterSynchronizerRequest_SYNCHRONIZER_CONNECTION_UNSPECIFIED
+	}
+}
+
+func durationToProto(d *time.Duration) *durationpb.Duration {
+	if d == nil {
+		return nil
+	}
+	return durationpb.New(*d)
+}
+
+func durationFromProto(pb *durationpb.Duration) *time.Duration {
+	if pb == nil {
+		return nil
+	}
+	d := pb.AsDuration()
+	return &d
+}
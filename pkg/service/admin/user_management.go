@@ -2,8 +2,10 @@ package admin
 
 import (
 	"context"
+	"iter"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/noders-team/go-daml/pkg/model"
 	adminv2 "github.com/noders-team/go-daml/proto/com/daml/ledger/api/v2/admin"
@@ -11,12 +13,20 @@ import (
 
 type UserManagement interface {
 	CreateUser(ctx context.Context, user *model.User, rights []*model.Right) (*model.User, error)
-	GetUser(ctx context.Context, userID string) (*model.User, error)
+	GetUser(ctx context.Context, userID, identityProviderID string) (*model.User, error)
 	DeleteUser(ctx context.Context, userID string) error
 	GrantUserRights(ctx context.Context, userID, identityProviderID string, rights []*model.Right) ([]*model.Right, error)
-	RevokeUserRights(ctx context.Context, userID string, rights []*model.Right) ([]*model.Right, error)
-	ListUserRights(ctx context.Context, userID string) ([]*model.Right, error)
+	RevokeUserRights(ctx context.Context, userID, identityProviderID string, rights []*model.Right) ([]*model.Right, error)
+	ListUserRights(ctx context.Context, userID, identityProviderID string) ([]*model.Right, error)
+	// ListUsers returns all users, following page tokens until the last page.
 	ListUsers(ctx context.Context) ([]*model.User, error)
+	ListUsersPage(ctx context.Context, pageToken string, pageSize int32) (*model.ListUsersResponse, error)
+	// AllUsers iterates over all users, fetching pages of pageSize lazily.
+	AllUsers(ctx context.Context, pageSize int32) iter.Seq2[*model.User, error]
+	// UpdateUser updates the fields of user selected by updateMask. Annotations
+	// are merged into the existing ones; an empty value removes a key.
+	UpdateUser(ctx context.Context, user *model.User, updateMask *model.UpdateMask) (*model.User, error)
+	UpdateUserIdentityProviderID(ctx context.Context, userID string, sourceIdentityProviderID string, targetIdentityProviderID string) error
 }
 
 type userManagement struct {
@@ -44,9 +54,10 @@ func (c *userManagement) CreateUser(ctx context.Context, user *model.User, right
 	return userFromProto(resp.GetUser()), nil
 }
 
-func (c *userManagement) GetUser(ctx context.Context, userID string) (*model.User, error) {
+func (c *userManagement) GetUser(ctx context.Context, userID, identityProviderID string) (*model.User, error) {
 	req := &adminv2.GetUserRequest{
-		UserId: userID,
+		UserId:             userID,
+		IdentityProviderId: identityProviderID,
 	}
 
 	resp, err := c.client.GetUser(ctx, req)
@@ -58,14 +69,86 @@ func (c *userManagement) GetUser(ctx context.Context, userID string) (*model.Use
 }
 
 func (c *userManagement) ListUsers(ctx context.Context) ([]*model.User, error) {
-	req := &adminv2.ListUsersRequest{}
+	var users []*model.User
+	for user, err := range c.AllUsers(ctx, 0) {
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (c *userManagement) ListUsersPage(ctx context.Context, pageToken string, pageSize int32) (*model.ListUsersResponse, error) {
+	req := &adminv2.ListUsersRequest{
+		PageToken: pageToken,
+		PageSize:  pageSize,
+	}
 
 	resp, err := c.client.ListUsers(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	return usersFromProto(resp.Users), nil
+	return &model.ListUsersResponse{
+		Users:         usersFromProto(resp.Users),
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+func (c *userManagement) AllUsers(ctx context.Context, pageSize int32) iter.Seq2[*model.User, error] {
+	return func(yield func(*model.User, error) bool) {
+		pageToken := ""
+		for {
+			page, err := c.ListUsersPage(ctx, pageToken, pageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, user := range page.Users {
+				if !yield(user, nil) {
+					return
+				}
+			}
+
+			if page.NextPageToken == "" {
+				return
+			}
+			pageToken = page.NextPageToken
+		}
+	}
+}
+
+func (c *userManagement) UpdateUser(ctx context.Context, user *model.User, updateMask *model.UpdateMask) (*model.User, error) {
+	req := &adminv2.UpdateUserRequest{
+		User: userToProto(user),
+	}
+
+	if updateMask != nil && len(updateMask.Paths) > 0 {
+		req.UpdateMask = &fieldmaskpb.FieldMask{
+			Paths: updateMask.Paths,
+		}
+	}
+
+	resp, err := c.client.UpdateUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return userFromProto(resp.User), nil
+}
+
+func (c *userManagement) UpdateUserIdentityProviderID(ctx context.Context, userID string, sourceIdentityProviderID string, targetIdentityProviderID string) error {
+	req := &adminv2.UpdateUserIdentityProviderIdRequest{
+		UserId:                   userID,
+		SourceIdentityProviderId: sourceIdentityProviderID,
+		TargetIdentityProviderId: targetIdentityProviderID,
+	}
+
+	_, err := c.client.UpdateUserIdentityProviderId(ctx, req)
+	return err
 }
 
 func (c *userManagement) DeleteUser(ctx context.Context, userID string) error {
@@ -96,10 +179,11 @@ func (c *userManagement) GrantUserRights(ctx context.Context, userID, identityPr
 	return rightsFromProto(resp.NewlyGrantedRights), nil
 }
 
-func (c *userManagement) RevokeUserRights(ctx context.Context, userID string, rights []*model.Right) ([]*model.Right, error) {
+func (c *userManagement) RevokeUserRights(ctx context.Context, userID, identityProviderID string, rights []*model.Right) ([]*model.Right, error) {
 	req := &adminv2.RevokeUserRightsRequest{
-		UserId: userID,
-		Rights: rightsToProto(rights),
+		UserId:             userID,
+		IdentityProviderId: identityProviderID,
+		Rights:             rightsToProto(rights),
 	}
 
 	resp, err := c.client.RevokeUserRights(ctx, req)
@@ -110,9 +194,10 @@ func (c *userManagement) RevokeUserRights(ctx context.Context, userID string, ri
 	return rightsFromProto(resp.NewlyRevokedRights), nil
 }
 
-func (c *userManagement) ListUserRights(ctx context.Context, userID string) ([]*model.Right, error) {
+func (c *userManagement) ListUserRights(ctx context.Context, userID, identityProviderID string) ([]*model.Right, error) {
 	req := &adminv2.ListUserRightsRequest{
-		UserId: userID,
+		UserId:             userID,
+		IdentityProviderId: identityProviderID,
 	}
 
 	resp, err := c.client.ListUserRights(ctx, req)
@@ -128,8 +213,10 @@ func userFromProto(pb *adminv2.User) *model.User {
 		return nil
 	}
 	metadata := make(map[string]string)
+	var resourceVersion string
 	if pb.Metadata != nil {
 		metadata = pb.Metadata.Annotations
+		resourceVersion = pb.Metadata.ResourceVersion
 	}
 	return &model.User{
 		ID:                 pb.Id,
@@ -137,6 +224,7 @@ func userFromProto(pb *adminv2.User) *model.User {
 		IsDeactivated:      pb.IsDeactivated,
 		Metadata:           metadata,
 		IdentityProviderID: pb.IdentityProviderId,
+		ResourceVersion:    resourceVersion,
 	}
 }
 
@@ -145,9 +233,10 @@ func userToProto(u *model.User) *adminv2.User {
 		return nil
 	}
 	var metadata *adminv2.ObjectMeta
-	if len(u.Metadata) > 0 {
+	if len(u.Metadata) > 0 || u.ResourceVersion != "" {
 		metadata = &adminv2.ObjectMeta{
-			Annotations: u.Metadata,
+			Annotations:     u.Metadata,
+			ResourceVersion: u.ResourceVersion,
 		}
 	}
 	return &adminv2.User{
@@ -0,0 +1,119 @@
+package admin_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListUsersPaginated(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cl := testutil.GetClient()
+	require.NotNil(t, cl)
+
+	suffix := time.Now().UnixNano()
+	created := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		userID := fmt.Sprintf("paging-user-%d-%d", suffix, i)
+		_, err := cl.UserMng.CreateUser(ctx, &model.User{ID: userID}, nil)
+		require.NoError(t, err)
+		created[userID] = false
+	}
+
+	page, err := cl.UserMng.ListUsersPage(ctx, "", 1)
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+	require.NotEmpty(t, page.NextPageToken)
+
+	for user, err := range cl.UserMng.AllUsers(ctx, 1) {
+		require.NoError(t, err)
+		if _, ok := created[user.ID]; ok {
+			created[user.ID] = true
+		}
+	}
+	for userID, seen := range created {
+		require.True(t, seen, "user %s not returned by AllUsers", userID)
+	}
+
+	users, err := cl.UserMng.ListUsers(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(users), len(created))
+}
+
+func TestUpdateUserAnnotations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cl := testutil.GetClient()
+	require.NotNil(t, cl)
+
+	userID := fmt.Sprintf("annotated-user-%d", time.Now().UnixNano())
+	_, err := cl.UserMng.CreateUser(ctx, &model.User{ID: userID}, nil)
+	require.NoError(t, err)
+
+	user, err := cl.UserMng.GetUser(ctx, userID, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, user.ResourceVersion)
+
+	mask := &model.UpdateMask{Paths: []string{model.UserUpdatePathAnnotations}}
+	updated, err := cl.UserMng.UpdateUser(ctx, &model.User{
+		ID:              userID,
+		Metadata:        map[string]string{"team": "payments"},
+		ResourceVersion: user.ResourceVersion,
+	}, mask)
+	require.NoError(t, err)
+	require.Equal(t, "payments", updated.Metadata["team"])
+	require.NotEqual(t, user.ResourceVersion, updated.ResourceVersion)
+
+	_, err = cl.UserMng.UpdateUser(ctx, &model.User{
+		ID:              userID,
+		Metadata:        map[string]string{"team": "treasury"},
+		ResourceVersion: user.ResourceVersion,
+	}, mask)
+	require.Error(t, err, "update with a stale resource version must fail")
+
+	updated, err = cl.UserMng.UpdateUser(ctx, &model.User{
+		ID:            userID,
+		IsDeactivated: true,
+	}, &model.UpdateMask{Paths: []string{model.UserUpdatePathIsDeactivated}})
+	require.NoError(t, err)
+	require.True(t, updated.IsDeactivated)
+	require.Equal(t, "payments", updated.Metadata["team"])
+}
+
+func TestUserRightsEnforced(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cl := testutil.GetClient()
+	require.NotNil(t, cl)
+
+	userID := fmt.Sprintf("rights-user-%d", time.Now().UnixNano())
+	_, err := cl.UserMng.CreateUser(ctx, &model.User{ID: userID}, nil)
+	require.NoError(t, err)
+
+	userCl, err := testutil.UserClient(ctx, userID)
+	require.NoError(t, err)
+	defer userCl.Close()
+
+	_, err = userCl.UserMng.ListUsers(ctx)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = cl.UserMng.GrantUserRights(ctx, userID, "", []*model.Right{{Type: model.ParticipantAdmin{}}})
+	require.NoError(t, err)
+
+	// the participant caches user rights for a few seconds
+	require.Eventually(t, func() bool {
+		_, err := userCl.UserMng.ListUsers(ctx)
+		return err == nil
+	}, 30*time.Second, time.Second)
+}
@@ -0,0 +1,306 @@
+package admin
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/crypto"
+	"github.com/noders-team/go-daml/pkg/model"
+	cryptoadminv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/crypto/admin/v30"
+	cryptov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/crypto/v30"
+)
+
+type Vault interface {
+	ListMyKeys(ctx context.Context, filter *model.ListKeysFilter) ([]*model.PrivateKeyDescriptor, error)
+	ListPublicKeys(ctx context.Context, filter *model.ListKeysFilter) ([]*model.KeyDescriptor, error)
+	GenerateSigningKey(ctx context.Context, req *model.GenerateSigningKeyRequest) (*model.KeyDescriptor, error)
+	GenerateEncryptionKey(ctx context.Context, req *model.GenerateEncryptionKeyRequest) (*model.KeyDescriptor, error)
+	RegisterKmsSigningKey(ctx context.Context, req *model.RegisterKmsSigningKeyRequest) (*model.KeyDescriptor, error)
+	RegisterKmsEncryptionKey(ctx context.Context, req *model.RegisterKmsEncryptionKeyRequest) (*model.KeyDescriptor, error)
+	// ImportPublicKey imports a serialized Canton public key and returns its
+	// fingerprint.
+	ImportPublicKey(ctx context.Context, publicKey []byte, name string) (string, error)
+	ExportKeyPair(ctx context.Context, req *model.ExportKeyPairRequest) ([]byte, error)
+	ImportKeyPair(ctx context.Context, req *model.ImportKeyPairRequest) error
+	DeleteKeyPair(ctx context.Context, fingerprint string) error
+	RotateWrapperKey(ctx context.Context, newWrapperKeyID string) error
+	GetWrapperKeyID(ctx context.Context) (string, error)
+}
+
+type vault struct {
+	client cryptoadminv30.VaultServiceClient
+}
+
+func NewVaultClient(conn *grpc.ClientConn) *vault {
+	client := cryptoadminv30.NewVaultServiceClient(conn)
+	return &vault{
+		client: client,
+	}
+}
+
+func (c *vault) ListMyKeys(ctx context.Context, filter *model.ListKeysFilter) ([]*model.PrivateKeyDescriptor, error) {
+	req := &cryptoadminv30.ListMyKeysRequest{
+		Filters: listKeysFilterToProto(filter),
+	}
+
+	resp, err := c.client.ListMyKeys(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.PrivateKeyDescriptor, len(resp.PrivateKeysMetadata))
+	for i, meta := range resp.PrivateKeysMetadata {
+		result[i] = &model.PrivateKeyDescriptor{
+			Key:          publicKeyWithNameFromProto(meta.PublicKeyWithName),
+			WrapperKeyID: meta.GetWrapperKeyId(),
+			KmsKeyID:     meta.GetKmsKeyId(),
+		}
+	}
+
+	return result, nil
+}
+
+func (c *vault) ListPublicKeys(ctx context.Context, filter *model.ListKeysFilter) ([]*model.KeyDescriptor, error) {
+	req := &cryptoadminv30.ListPublicKeysRequest{
+		Filters: listKeysFilterToProto(filter),
+	}
+
+	resp, err := c.client.ListPublicKeys(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.KeyDescriptor, len(resp.PublicKeys))
+	for i, key := range resp.PublicKeys {
+		result[i] = publicKeyWithNameFromProto(key)
+	}
+
+	return result, nil
+}
+
+func (c *vault) GenerateSigningKey(ctx context.Context, req *model.GenerateSigningKeyRequest) (*model.KeyDescriptor, error) {
+	protoReq := &cryptoadminv30.GenerateSigningKeyRequest{
+		KeySpec: cryptov30.SigningKeySpec(req.KeySpec),
+		Name:    req.Name,
+		Usage:   signingKeyUsagesToProto(req.Usage),
+	}
+
+	resp, err := c.client.GenerateSigningKey(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return signingKeyDescriptorFromProto(resp.PublicKey, req.Name), nil
+}
+
+func (c *vault) GenerateEncryptionKey(ctx context.Context, req *model.GenerateEncryptionKeyRequest) (*model.KeyDescriptor, error) {
+	protoReq := &cryptoadminv30.GenerateEncryptionKeyRequest{
+		KeySpec: cryptov30.EncryptionKeySpec(req.KeySpec),
+		Name:    req.Name,
+	}
+
+	resp, err := c.client.GenerateEncryptionKey(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return encryptionKeyDescriptorFromProto(resp.PublicKey, req.Name), nil
+}
+
+func (c *vault) RegisterKmsSigningKey(ctx context.Context, req *model.RegisterKmsSigningKeyRequest) (*model.KeyDescriptor, error) {
+	protoReq := &cryptoadminv30.RegisterKmsSigningKeyRequest{
+		KmsKeyId: req.KmsKeyID,
+		Name:     req.Name,
+		Usage:    signingKeyUsagesToProto(req.Usage),
+	}
+
+	resp, err := c.client.RegisterKmsSigningKey(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return signingKeyDescriptorFromProto(resp.PublicKey, req.Name), nil
+}
+
+func (c *vault) RegisterKmsEncryptionKey(ctx context.Context, req *model.RegisterKmsEncryptionKeyRequest) (*model.KeyDescriptor, error) {
+	protoReq := &cryptoadminv30.RegisterKmsEncryptionKeyRequest{
+		KmsKeyId: req.KmsKeyID,
+		Name:     req.Name,
+	}
+
+	resp, err := c.client.RegisterKmsEncryptionKey(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return encryptionKeyDescriptorFromProto(resp.PublicKey, req.Name), nil
+}
+
+func (c *vault) ImportPublicKey(ctx context.Context, publicKey []byte, name string) (string, error) {
+	req := &cryptoadminv30.ImportPublicKeyRequest{
+		PublicKey: publicKey,
+		Name:      name,
+	}
+
+	resp, err := c.client.ImportPublicKey(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Fingerprint, nil
+}
+
+func (c *vault) ExportKeyPair(ctx context.Context, req *model.ExportKeyPairRequest) ([]byte, error) {
+	protoReq := &cryptoadminv30.ExportKeyPairRequest{
+		Fingerprint:     req.Fingerprint,
+		ProtocolVersion: req.ProtocolVersion,
+		Password:        req.Password,
+	}
+
+	resp, err := c.client.ExportKeyPair(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.KeyPair, nil
+}
+
+func (c *vault) ImportKeyPair(ctx context.Context, req *model.ImportKeyPairRequest) error {
+	protoReq := &cryptoadminv30.ImportKeyPairRequest{
+		KeyPair:  req.KeyPair,
+		Name:     req.Name,
+		Password: req.Password,
+	}
+
+	_, err := c.client.ImportKeyPair(ctx, protoReq)
+	return err
+}
+
+func (c *vault) DeleteKeyPair(ctx context.Context, fingerprint string) error {
+	req := &cryptoadminv30.DeleteKeyPairRequest{
+		Fingerprint: fingerprint,
+	}
+
+	_, err := c.client.DeleteKeyPair(ctx, req)
+	return err
+}
+
+func (c *vault) RotateWrapperKey(ctx context.Context, newWrapperKeyID string) error {
+	req := &cryptoadminv30.RotateWrapperKeyRequest{
+		NewWrapperKeyId: newWrapperKeyID,
+	}
+
+	_, err := c.client.RotateWrapperKey(ctx, req)
+	return err
+}
+
+func (c *vault) GetWrapperKeyID(ctx context.Context) (string, error) {
+	resp, err := c.client.GetWrapperKeyId(ctx, &cryptoadminv30.GetWrapperKeyIdRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.WrapperKeyId, nil
+}
+
+func listKeysFilterToProto(filter *model.ListKeysFilter) *cryptoadminv30.ListKeysFilters {
+	if filter == nil {
+		return &cryptoadminv30.ListKeysFilters{}
+	}
+
+	purposes := make([]cryptov30.KeyPurpose, len(filter.Purposes))
+	for i, p := range filter.Purposes {
+		purposes[i] = cryptov30.KeyPurpose(p)
+	}
+
+	return &cryptoadminv30.ListKeysFilters{
+		Fingerprint: filter.Fingerprint,
+		Name:        filter.Name,
+		Purpose:     purposes,
+		Usage:       signingKeyUsagesToProto(filter.Usages),
+	}
+}
+
+func signingKeyUsagesToProto(usages []model.SigningKeyUsage) []cryptov30.SigningKeyUsage {
+	result := make([]cryptov30.SigningKeyUsage, len(usages))
+	for i, u := range usages {
+		result[i] = cryptov30.SigningKeyUsage(u)
+	}
+	return result
+}
+
+func publicKeyWithNameFromProto(pb *cryptov30.PublicKeyWithName) *model.KeyDescriptor {
+	if pb == nil || pb.PublicKey == nil {
+		return nil
+	}
+
+	switch key := pb.PublicKey.Key.(type) {
+	case *cryptov30.PublicKey_SigningPublicKey:
+		return signingKeyDescriptorFromProto(key.SigningPublicKey, pb.Name)
+	case *cryptov30.PublicKey_EncryptionPublicKey:
+		return encryptionKeyDescriptorFromProto(key.EncryptionPublicKey, pb.Name)
+	default:
+		return &model.KeyDescriptor{Name: pb.Name}
+	}
+}
+
+func signingKeyDescriptorFromProto(pb *cryptov30.SigningPublicKey, name string) *model.KeyDescriptor {
+	if pb == nil {
+		return nil
+	}
+
+	usage := make([]model.SigningKeyUsage, len(pb.Usage))
+	for i, u := range pb.Usage {
+		usage[i] = model.SigningKeyUsage(u)
+	}
+
+	return &model.KeyDescriptor{
+		Fingerprint:    keyFingerprint(pb.Format, pb.PublicKey),
+		Name:           name,
+		Purpose:        model.KeyPurposeSigning,
+		Format:         model.CryptoKeyFormat(pb.Format),
+		PublicKey:      pb.PublicKey,
+		SigningKeySpec: model.SigningKeySpec(pb.KeySpec),
+		Usage:          usage,
+	}
+}
+
+func encryptionKeyDescriptorFromProto(pb *cryptov30.EncryptionPublicKey, name string) *model.KeyDescriptor {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.KeyDescriptor{
+		Fingerprint:       keyFingerprint(pb.Format, pb.PublicKey),
+		Name:              name,
+		Purpose:           model.KeyPurposeEncryption,
+		Format:            model.CryptoKeyFormat(pb.Format),
+		PublicKey:         pb.PublicKey,
+		EncryptionKeySpec: model.EncryptionKeySpec(pb.KeySpec),
+	}
+}
+
+// keyFingerprint computes the Canton fingerprint of a public key. Canton hashes
+// Ed25519 keys in their raw form even when they are served as X.509
+// SubjectPublicKeyInfo, so those are unwrapped first.
+func keyFingerprint(format cryptov30.CryptoKeyFormat, key []byte) string {
+	data := key
+	if format == cryptov30.CryptoKeyFormat_CRYPTO_KEY_FORMAT_DER_X509_SUBJECT_PUBLIC_KEY_INFO {
+		if parsed, err := x509.ParsePKIXPublicKey(key); err == nil {
+			if edKey, ok := parsed.(ed25519.PublicKey); ok {
+				data = edKey
+			}
+		}
+	}
+
+	hash, err := crypto.ComputeSHA256CantonHash(crypto.CantonHashPurposePublicKeyFingerprint, data)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%x", hash)
+}
@@ -0,0 +1,61 @@
+// Package health converts the health status protos shared by all Canton nodes.
+package health
+
+import (
+	"github.com/noders-team/go-daml/pkg/model"
+	healthv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/health/v30"
+)
+
+// NodeStatusFromProto converts the common status of an initialized node.
+func NodeStatusFromProto(pb *healthv30.Status) model.NodeStatus {
+	if pb == nil {
+		return model.NodeStatus{Initialized: true}
+	}
+
+	return model.NodeStatus{
+		Initialized: true,
+		UID:         pb.Uid,
+		Uptime:      pb.Uptime.AsDuration(),
+		Ports:       pb.Ports,
+		Active:      pb.Active,
+		Version:     pb.Version,
+		Components:  ComponentStatusFromProtos(pb.Components),
+	}
+}
+
+func ComponentStatusFromProtos(pb []*healthv30.ComponentStatus) []*model.ComponentStatus {
+	components := make([]*model.ComponentStatus, len(pb))
+	for i, component := range pb {
+		components[i] = ComponentStatusFromProto(component)
+	}
+	return components
+}
+
+func ComponentStatusFromProto(pb *healthv30.ComponentStatus) *model.ComponentStatus {
+	if pb == nil {
+		return nil
+	}
+
+	status := &model.ComponentStatus{
+		Name: pb.Name,
+	}
+
+	var data *healthv30.ComponentStatus_StatusData
+	switch s := pb.Status.(type) {
+	case *healthv30.ComponentStatus_Ok:
+		status.Health = model.ComponentHealthOk
+		data = s.Ok
+	case *healthv30.ComponentStatus_Degraded:
+		status.Health = model.ComponentHealthDegraded
+		data = s.Degraded
+	case *healthv30.ComponentStatus_Failed:
+		status.Health = model.ComponentHealthFailed
+		data = s.Failed
+	case *healthv30.ComponentStatus_Fatal:
+		status.Health = model.ComponentHealthFatal
+		data = s.Fatal
+	}
+	status.Description = data.GetDescription()
+
+	return status
+}
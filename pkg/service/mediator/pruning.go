@@ -0,0 +1,103 @@
+package mediator
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	pruningconv "github.com/noders-team/go-daml/pkg/service/pruning"
+	pruningv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/pruning/v30"
+	mediatoradminv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/mediator/admin/v30"
+)
+
+type Pruning interface {
+	// Prune removes finalized requests up to timestamp.
+	Prune(ctx context.Context, timestamp time.Time) error
+	SetSchedule(ctx context.Context, schedule *model.PruningSchedule) error
+	SetCron(ctx context.Context, cron string) error
+	SetMaxDuration(ctx context.Context, maxDuration time.Duration) error
+	SetRetention(ctx context.Context, retention time.Duration) error
+	GetSchedule(ctx context.Context) (*model.PruningSchedule, error)
+	ClearSchedule(ctx context.Context) error
+	// FindPruningTimestamp returns the timestamp of the index-th stored request,
+	// or nil if fewer requests are stored.
+	FindPruningTimestamp(ctx context.Context, index int32) (*time.Time, error)
+}
+
+type pruning struct {
+	client mediatoradminv30.MediatorAdministrationServiceClient
+}
+
+func NewPruningClient(conn *grpc.ClientConn) *pruning {
+	return &pruning{
+		client: mediatoradminv30.NewMediatorAdministrationServiceClient(conn),
+	}
+}
+
+func (c *pruning) Prune(ctx context.Context, timestamp time.Time) error {
+	_, err := c.client.Prune(ctx, &mediatoradminv30.MediatorPruning_PruneRequest{
+		Timestamp: timestamppb.New(timestamp),
+	})
+	return err
+}
+
+func (c *pruning) SetSchedule(ctx context.Context, schedule *model.PruningSchedule) error {
+	_, err := c.client.SetSchedule(ctx, &pruningv30.SetScheduleRequest{
+		Schedule: pruningconv.ScheduleToProto(schedule),
+	})
+	return err
+}
+
+func (c *pruning) SetCron(ctx context.Context, cron string) error {
+	_, err := c.client.SetCron(ctx, &pruningv30.SetCronRequest{
+		Cron: cron,
+	})
+	return err
+}
+
+func (c *pruning) SetMaxDuration(ctx context.Context, maxDuration time.Duration) error {
+	_, err := c.client.SetMaxDuration(ctx, &pruningv30.SetMaxDurationRequest{
+		MaxDuration: durationpb.New(maxDuration),
+	})
+	return err
+}
+
+func (c *pruning) SetRetention(ctx context.Context, retention time.Duration) error {
+	_, err := c.client.SetRetention(ctx, &pruningv30.SetRetentionRequest{
+		Retention: durationpb.New(retention),
+	})
+	return err
+}
+
+func (c *pruning) GetSchedule(ctx context.Context) (*model.PruningSchedule, error) {
+	resp, err := c.client.GetSchedule(ctx, &pruningv30.GetScheduleRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return pruningconv.ScheduleFromProto(resp.Schedule), nil
+}
+
+func (c *pruning) ClearSchedule(ctx context.Context) error {
+	_, err := c.client.ClearSchedule(ctx, &pruningv30.ClearScheduleRequest{})
+	return err
+}
+
+func (c *pruning) FindPruningTimestamp(ctx context.Context, index int32) (*time.Time, error) {
+	resp, err := c.client.FindPruningTimestamp(ctx, &pruningv30.FindPruningTimestampRequest{
+		Index: index,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Timestamp == nil {
+		return nil, nil
+	}
+
+	t := resp.Timestamp.AsTime()
+	return &t, nil
+}
@@ -0,0 +1,70 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/health"
+	healthv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/health/v30"
+	mediatorv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/mediator/v30"
+)
+
+type Status interface {
+	Status(ctx context.Context) (*model.MediatorStatus, error)
+	SetLogLevel(ctx context.Context, level string) error
+}
+
+type status struct {
+	client       mediatorv30.MediatorStatusServiceClient
+	statusClient healthv30.StatusServiceClient
+}
+
+func NewStatusClient(conn *grpc.ClientConn) *status {
+	return &status{
+		client:       mediatorv30.NewMediatorStatusServiceClient(conn),
+		statusClient: healthv30.NewStatusServiceClient(conn),
+	}
+}
+
+func (c *status) Status(ctx context.Context) (*model.MediatorStatus, error) {
+	resp, err := c.client.MediatorStatus(ctx, &mediatorv30.MediatorStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind := resp.Kind.(type) {
+	case *mediatorv30.MediatorStatusResponse_Status:
+		return mediatorStatusFromProto(kind.Status), nil
+	case *mediatorv30.MediatorStatusResponse_NotInitialized:
+		return &model.MediatorStatus{
+			NodeStatus: model.NodeStatus{
+				Active:                  kind.NotInitialized.GetActive(),
+				WaitingForExternalInput: model.WaitingForExternalInput(kind.NotInitialized.GetWaitingForExternalInput()),
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected mediator status response: %T", resp.Kind)
+	}
+}
+
+func (c *status) SetLogLevel(ctx context.Context, level string) error {
+	_, err := c.statusClient.SetLogLevel(ctx, &healthv30.SetLogLevelRequest{
+		Level: level,
+	})
+	return err
+}
+
+func mediatorStatusFromProto(pb *mediatorv30.MediatorStatusResponse_MediatorStatusResponseStatus) *model.MediatorStatus {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.MediatorStatus{
+		NodeStatus:             health.NodeStatusFromProto(pb.CommonStatus),
+		PhysicalSynchronizerID: pb.PhysicalSynchronizerId,
+		ProtocolVersion:        pb.ProtocolVersion,
+	}
+}
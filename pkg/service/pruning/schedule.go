@@ -0,0 +1,34 @@
+// Package pruning converts the pruning schedule protos shared by the
+// participant, sequencer and mediator admin services.
+package pruning
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	pruningv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/pruning/v30"
+)
+
+func ScheduleToProto(schedule *model.PruningSchedule) *pruningv30.PruningSchedule {
+	if schedule == nil {
+		return nil
+	}
+
+	return &pruningv30.PruningSchedule{
+		Cron:        schedule.Cron,
+		MaxDuration: durationpb.New(schedule.MaxDuration),
+		Retention:   durationpb.New(schedule.Retention),
+	}
+}
+
+func ScheduleFromProto(pb *pruningv30.PruningSchedule) *model.PruningSchedule {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.PruningSchedule{
+		Cron:        pb.Cron,
+		MaxDuration: pb.MaxDuration.AsDuration(),
+		Retention:   pb.Retention.AsDuration(),
+	}
+}
@@ -0,0 +1,125 @@
+package sequencer
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	protocolv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/protocol/v30"
+	seqadminv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/sequencer/admin/v30"
+)
+
+type Administration interface {
+	PruningStatus(ctx context.Context) (*model.SequencerPruningStatus, error)
+	// TrafficControlState returns the traffic state of the requested members,
+	// keyed by member; all members are returned if none are requested.
+	TrafficControlState(ctx context.Context, req *model.TrafficControlStateRequest) (map[string]*model.MemberTrafficState, error)
+	SetTrafficPurchased(ctx context.Context, req *model.SetTrafficPurchasedRequest) error
+	// DisableMember prevents a member from using the sequencer and lets
+	// pruning move past its unacknowledged events.
+	DisableMember(ctx context.Context, member string) error
+}
+
+type administration struct {
+	client seqadminv30.SequencerAdministrationServiceClient
+}
+
+func NewAdministrationClient(conn *grpc.ClientConn) *administration {
+	return &administration{
+		client: seqadminv30.NewSequencerAdministrationServiceClient(conn),
+	}
+}
+
+func (c *administration) PruningStatus(ctx context.Context) (*model.SequencerPruningStatus, error) {
+	resp, err := c.client.PruningStatus(ctx, &seqadminv30.PruningStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return sequencerPruningStatusFromProto(resp.PruningStatus), nil
+}
+
+func (c *administration) TrafficControlState(ctx context.Context, req *model.TrafficControlStateRequest) (map[string]*model.MemberTrafficState, error) {
+	protoReq := &seqadminv30.TrafficControlStateRequest{
+		Members: req.Members,
+	}
+	if req.ExactTimestamp != nil {
+		protoReq.TimestampSelector = &seqadminv30.TrafficControlStateRequest_ExactTimestamp{
+			ExactTimestamp: uint64(req.ExactTimestamp.UnixMicro()),
+		}
+	} else {
+		protoReq.TimestampSelector = &seqadminv30.TrafficControlStateRequest_RelativeTimestamp_{
+			RelativeTimestamp: seqadminv30.TrafficControlStateRequest_RelativeTimestamp(req.RelativeTimestamp),
+		}
+	}
+
+	resp, err := c.client.TrafficControlState(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]*model.MemberTrafficState, len(resp.TrafficStates))
+	for member, state := range resp.TrafficStates {
+		states[member] = memberTrafficStateFromProto(state)
+	}
+
+	return states, nil
+}
+
+func (c *administration) SetTrafficPurchased(ctx context.Context, req *model.SetTrafficPurchasedRequest) error {
+	_, err := c.client.SetTrafficPurchased(ctx, &seqadminv30.SetTrafficPurchasedRequest{
+		Member:                req.Member,
+		Serial:                req.Serial,
+		TotalTrafficPurchased: req.TotalTrafficPurchased,
+	})
+	return err
+}
+
+func (c *administration) DisableMember(ctx context.Context, member string) error {
+	_, err := c.client.DisableMember(ctx, &seqadminv30.DisableMemberRequest{
+		Member: member,
+	})
+	return err
+}
+
+func sequencerPruningStatusFromProto(pb *seqadminv30.SequencerPruningStatus) *model.SequencerPruningStatus {
+	if pb == nil {
+		return nil
+	}
+
+	status := &model.SequencerPruningStatus{
+		Now:                    time.UnixMicro(pb.Now).UTC(),
+		EarliestEventTimestamp: time.UnixMicro(pb.EarliestEventTimestamp).UTC(),
+		Members:                make([]*model.SequencerMemberStatus, len(pb.Members)),
+	}
+	for i, member := range pb.Members {
+		status.Members[i] = &model.SequencerMemberStatus{
+			Member:       member.Member,
+			RegisteredAt: time.UnixMicro(member.RegisteredAt).UTC(),
+			Enabled:      member.Enabled,
+		}
+		if member.LastAcknowledged != nil {
+			t := time.UnixMicro(*member.LastAcknowledged).UTC()
+			status.Members[i].LastAcknowledged = &t
+		}
+	}
+
+	return status
+}
+
+func memberTrafficStateFromProto(pb *protocolv30.TrafficState) *model.MemberTrafficState {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.MemberTrafficState{
+		ExtraTrafficPurchased: pb.ExtraTrafficPurchased,
+		ExtraTrafficConsumed:  pb.ExtraTrafficConsumed,
+		BaseTrafficRemainder:  pb.BaseTrafficRemainder,
+		LastConsumedCost:      pb.LastConsumedCost,
+		Timestamp:             time.UnixMicro(pb.Timestamp).UTC(),
+		Serial:                pb.Serial,
+	}
+}
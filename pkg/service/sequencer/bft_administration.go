@@ -0,0 +1,208 @@
+package sequencer
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	seqadminv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/sequencer/admin/v30"
+)
+
+// BftAdministration manages the peer network of a sequencer backed by the BFT
+// ordering service.
+type BftAdministration interface {
+	// AddPeerEndpoint returns false if the endpoint is already configured.
+	AddPeerEndpoint(ctx context.Context, endpoint *model.PeerEndpoint) (bool, error)
+	// RemovePeerEndpoint returns false if the endpoint is not configured.
+	RemovePeerEndpoint(ctx context.Context, id *model.PeerEndpointID) (bool, error)
+	// GetPeerNetworkStatus returns the status of the given endpoints, or of all
+	// known endpoints and incoming connections if none are given.
+	GetPeerNetworkStatus(ctx context.Context, ids []*model.PeerEndpointID) ([]*model.PeerConnectionStatus, error)
+	GetWriteReadiness(ctx context.Context) (*model.BftWriteReadiness, error)
+	GetOrderingTopology(ctx context.Context) (*model.BftOrderingTopology, error)
+	SetPerformanceMetricsEnabled(ctx context.Context, enabled bool) error
+}
+
+type bftAdministration struct {
+	client seqadminv30.SequencerBftAdministrationServiceClient
+}
+
+func NewBftAdministrationClient(conn *grpc.ClientConn) *bftAdministration {
+	return &bftAdministration{
+		client: seqadminv30.NewSequencerBftAdministrationServiceClient(conn),
+	}
+}
+
+func (c *bftAdministration) AddPeerEndpoint(ctx context.Context, endpoint *model.PeerEndpoint) (bool, error) {
+	resp, err := c.client.AddPeerEndpoint(ctx, &seqadminv30.AddPeerEndpointRequest{
+		Endpoint: peerEndpointToProto(endpoint),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Added, nil
+}
+
+func (c *bftAdministration) RemovePeerEndpoint(ctx context.Context, id *model.PeerEndpointID) (bool, error) {
+	resp, err := c.client.RemovePeerEndpoint(ctx, &seqadminv30.RemovePeerEndpointRequest{
+		EndpointId: peerEndpointIDToProto(id),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Removed, nil
+}
+
+func (c *bftAdministration) GetPeerNetworkStatus(ctx context.Context, ids []*model.PeerEndpointID) ([]*model.PeerConnectionStatus, error) {
+	endpointIDs := make([]*seqadminv30.PeerEndpointId, len(ids))
+	for i, id := range ids {
+		endpointIDs[i] = peerEndpointIDToProto(id)
+	}
+
+	resp, err := c.client.GetPeerNetworkStatus(ctx, &seqadminv30.GetPeerNetworkStatusRequest{
+		EndpointIds: endpointIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*model.PeerConnectionStatus, 0, len(resp.Statuses))
+	for _, pb := range resp.Statuses {
+		status, err := peerConnectionStatusFromProto(pb)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (c *bftAdministration) GetWriteReadiness(ctx context.Context) (*model.BftWriteReadiness, error) {
+	resp, err := c.client.GetWriteReadiness(ctx, &seqadminv30.GetWriteReadinessRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	readiness := &model.BftWriteReadiness{}
+	var p2p *seqadminv30.GetWriteReadinessResponse_P2P
+	switch r := resp.Readiness.(type) {
+	case *seqadminv30.GetWriteReadinessResponse_Ready_:
+		readiness.Ready = true
+		p2p = r.Ready.GetP2P()
+	case *seqadminv30.GetWriteReadinessResponse_P2PNotReady_:
+		p2p = r.P2PNotReady.GetP2P()
+	default:
+		return nil, fmt.Errorf("unexpected write readiness response: %T", resp.Readiness)
+	}
+	readiness.AuthenticatedPeersCount = p2p.GetAuthenticatedPeersCount()
+	readiness.RequiredQuorum = p2p.GetRequiredQuorum()
+
+	return readiness, nil
+}
+
+func (c *bftAdministration) GetOrderingTopology(ctx context.Context) (*model.BftOrderingTopology, error) {
+	resp, err := c.client.GetOrderingTopology(ctx, &seqadminv30.GetOrderingTopologyRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BftOrderingTopology{
+		CurrentEpoch: resp.CurrentEpoch,
+		SequencerIDs: resp.SequencerIds,
+	}, nil
+}
+
+func (c *bftAdministration) SetPerformanceMetricsEnabled(ctx context.Context, enabled bool) error {
+	_, err := c.client.SetPerformanceMetricsEnabled(ctx, &seqadminv30.SetPerformanceMetricsEnabledRequest{
+		Enabled: enabled,
+	})
+	return err
+}
+
+func peerEndpointToProto(endpoint *model.PeerEndpoint) *seqadminv30.PeerEndpoint {
+	if endpoint == nil {
+		return nil
+	}
+
+	pb := &seqadminv30.PeerEndpoint{
+		Address: endpoint.Address,
+		Port:    endpoint.Port,
+	}
+	if endpoint.TLS == nil {
+		pb.Security = &seqadminv30.PeerEndpoint_PlainText{PlainText: &seqadminv30.PlainTextPeerEndpoint{}}
+		return pb
+	}
+
+	tls := &seqadminv30.TlsPeerEndpoint{
+		CustomServerTrustCertificate: endpoint.TLS.CustomServerTrustCertificate,
+	}
+	if len(endpoint.TLS.ClientCertificateChain) > 0 {
+		tls.ClientCertificate = &seqadminv30.TlsClientCertificate{
+			CertificateChain: endpoint.TLS.ClientCertificateChain,
+			PrivateKeyFile:   endpoint.TLS.ClientPrivateKeyFile,
+		}
+	}
+	pb.Security = &seqadminv30.PeerEndpoint_Tls{Tls: tls}
+
+	return pb
+}
+
+func peerEndpointIDToProto(id *model.PeerEndpointID) *seqadminv30.PeerEndpointId {
+	if id == nil {
+		return nil
+	}
+
+	return &seqadminv30.PeerEndpointId{
+		Address: id.Address,
+		Port:    id.Port,
+		Tls:     id.TLS,
+	}
+}
+
+func peerEndpointIDFromProto(pb *seqadminv30.PeerEndpointId) *model.PeerEndpointID {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.PeerEndpointID{
+		Address: pb.Address,
+		Port:    pb.Port,
+		TLS:     pb.Tls,
+	}
+}
+
+func peerConnectionStatusFromProto(pb *seqadminv30.PeerConnectionStatus) (*model.PeerConnectionStatus, error) {
+	switch s := pb.GetStatus().(type) {
+	case *seqadminv30.PeerConnectionStatus_PeerEndpointStatus:
+		endpoint := s.PeerEndpointStatus
+		status := &model.PeerConnectionStatus{
+			EndpointID:  peerEndpointIDFromProto(endpoint.EndpointId),
+			Outgoing:    endpoint.IsOutgoingConnection,
+			Description: endpoint.GetHealth().GetDescription(),
+		}
+		switch h := endpoint.GetHealth().GetStatus().GetStatus().(type) {
+		case *seqadminv30.PeerEndpointHealthStatus_UnknownEndpoint_:
+			status.Health = model.PeerEndpointHealthUnknownEndpoint
+		case *seqadminv30.PeerEndpointHealthStatus_Disconnected_:
+			status.Health = model.PeerEndpointHealthDisconnected
+		case *seqadminv30.PeerEndpointHealthStatus_Unauthenticated_:
+			status.Health = model.PeerEndpointHealthUnauthenticated
+		case *seqadminv30.PeerEndpointHealthStatus_Authenticated:
+			status.Health = model.PeerEndpointHealthAuthenticated
+			status.SequencerID = h.Authenticated.GetSequencerId()
+		}
+		return status, nil
+	case *seqadminv30.PeerConnectionStatus_PeerIncomingConnection:
+		return &model.PeerConnectionStatus{
+			Health:      model.PeerEndpointHealthAuthenticated,
+			SequencerID: s.PeerIncomingConnection.GetSequencerId(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected peer connection status: %T", pb.GetStatus())
+	}
+}
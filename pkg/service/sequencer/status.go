@@ -0,0 +1,88 @@
+package sequencer
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/health"
+	healthv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/health/v30"
+	sequencerv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/admin/sequencer/v30"
+)
+
+type Status interface {
+	Status(ctx context.Context) (*model.SequencerStatus, error)
+	SetLogLevel(ctx context.Context, level string) error
+}
+
+type status struct {
+	client       sequencerv30.SequencerStatusServiceClient
+	statusClient healthv30.StatusServiceClient
+}
+
+func NewStatusClient(conn *grpc.ClientConn) *status {
+	return &status{
+		client:       sequencerv30.NewSequencerStatusServiceClient(conn),
+		statusClient: healthv30.NewStatusServiceClient(conn),
+	}
+}
+
+func (c *status) Status(ctx context.Context) (*model.SequencerStatus, error) {
+	resp, err := c.client.SequencerStatus(ctx, &sequencerv30.SequencerStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind := resp.Kind.(type) {
+	case *sequencerv30.SequencerStatusResponse_Status:
+		return sequencerStatusFromProto(kind.Status), nil
+	case *sequencerv30.SequencerStatusResponse_NotInitialized:
+		return &model.SequencerStatus{
+			NodeStatus: model.NodeStatus{
+				Active:                  kind.NotInitialized.GetActive(),
+				WaitingForExternalInput: model.WaitingForExternalInput(kind.NotInitialized.GetWaitingForExternalInput()),
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected sequencer status response: %T", resp.Kind)
+	}
+}
+
+func (c *status) SetLogLevel(ctx context.Context, level string) error {
+	_, err := c.statusClient.SetLogLevel(ctx, &healthv30.SetLogLevelRequest{
+		Level: level,
+	})
+	return err
+}
+
+func sequencerStatusFromProto(pb *sequencerv30.SequencerStatusResponse_SequencerStatusResponseStatus) *model.SequencerStatus {
+	if pb == nil {
+		return nil
+	}
+
+	status := &model.SequencerStatus{
+		NodeStatus:             health.NodeStatusFromProto(pb.CommonStatus),
+		PhysicalSynchronizerID: pb.PhysicalSynchronizerId,
+		ProtocolVersion:        pb.ProtocolVersion,
+	}
+
+	status.ConnectedParticipants = make([]string, len(pb.ConnectedParticipants))
+	for i, participant := range pb.ConnectedParticipants {
+		status.ConnectedParticipants[i] = participant.Uid
+	}
+	status.ConnectedMediators = make([]string, len(pb.ConnectedMediators))
+	for i, mediator := range pb.ConnectedMediators {
+		status.ConnectedMediators[i] = mediator.Uid
+	}
+	if pb.Sequencer != nil {
+		status.SequencerActive = pb.Sequencer.Active
+		status.SequencerDetails = pb.Sequencer.GetDetails()
+	}
+	if pb.Admin != nil {
+		status.AcceptsAdminChanges = pb.Admin.AcceptsAdminChanges
+	}
+
+	return status
+}
@@ -0,0 +1,69 @@
+package sequencer
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	seqadminv30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/sequencer/admin/v30"
+)
+
+type TrafficInspection interface {
+	// GetTrafficSummaries returns what was paid for the events sequenced at the
+	// given times. Times without a sequenced event are left out of the result.
+	GetTrafficSummaries(ctx context.Context, sequencingTimes []time.Time) ([]*model.TrafficSummary, error)
+}
+
+type trafficInspection struct {
+	client seqadminv30.SequencerTrafficInspectionServiceClient
+}
+
+func NewTrafficInspectionClient(conn *grpc.ClientConn) *trafficInspection {
+	return &trafficInspection{
+		client: seqadminv30.NewSequencerTrafficInspectionServiceClient(conn),
+	}
+}
+
+func (c *trafficInspection) GetTrafficSummaries(ctx context.Context, sequencingTimes []time.Time) ([]*model.TrafficSummary, error) {
+	timestamps := make([]*timestamppb.Timestamp, len(sequencingTimes))
+	for i, t := range sequencingTimes {
+		timestamps[i] = timestamppb.New(t)
+	}
+
+	resp, err := c.client.GetTrafficSummaries(ctx, &seqadminv30.GetTrafficSummariesRequest{
+		SequencingTimestamps: timestamps,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*model.TrafficSummary, len(resp.Summary))
+	for i, summary := range resp.Summary {
+		summaries[i] = trafficSummaryFromProto(summary)
+	}
+
+	return summaries, nil
+}
+
+func trafficSummaryFromProto(pb *seqadminv30.TrafficSummary) *model.TrafficSummary {
+	if pb == nil {
+		return nil
+	}
+
+	summary := &model.TrafficSummary{
+		SequencingTime:   pb.SequencingTime.AsTime(),
+		TotalTrafficCost: pb.TotalTrafficCost,
+		Envelopes:        make([]*model.EnvelopeTrafficSummary, len(pb.Envelopes)),
+	}
+	for i, envelope := range pb.Envelopes {
+		summary.Envelopes[i] = &model.EnvelopeTrafficSummary{
+			TrafficCost: envelope.EnvelopeTrafficCost,
+			ViewHashes:  envelope.ViewHashes,
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,64 @@
+package topology
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	topov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/topology/admin/v30"
+)
+
+// IdentityInitialization binds a node configured for manual identity
+// initialization to its unique identifier.
+type IdentityInitialization interface {
+	InitID(ctx context.Context, req *model.InitIDRequest) error
+	GetID(ctx context.Context) (*model.GetIDResponse, error)
+	CurrentTime(ctx context.Context) (time.Time, error)
+}
+
+type identityInitialization struct {
+	client topov30.IdentityInitializationServiceClient
+}
+
+func NewIdentityInitializationClient(conn *grpc.ClientConn) *identityInitialization {
+	client := topov30.NewIdentityInitializationServiceClient(conn)
+	return &identityInitialization{
+		client: client,
+	}
+}
+
+func (c *identityInitialization) InitID(ctx context.Context, req *model.InitIDRequest) error {
+	protoReq := &topov30.InitIdRequest{
+		Identifier:           req.Identifier,
+		Namespace:            req.Namespace,
+		NamespaceDelegations: signedTopologyTransactionsToProto(req.NamespaceDelegations),
+	}
+
+	_, err := c.client.InitId(ctx, protoReq)
+	return err
+}
+
+func (c *identityInitialization) GetID(ctx context.Context) (*model.GetIDResponse, error) {
+	resp, err := c.client.GetId(ctx, &topov30.GetIdRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GetIDResponse{
+		Initialized:      resp.Initialized,
+		UniqueIdentifier: resp.UniqueIdentifier,
+	}, nil
+}
+
+// CurrentTime returns the clock of the node, which differs from the wall clock
+// when the node runs with static time.
+func (c *identityInitialization) CurrentTime(ctx context.Context) (time.Time, error) {
+	resp, err := c.client.CurrentTime(ctx, &topov30.CurrentTimeRequest{})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.UnixMicro(resp.CurrentTime).UTC(), nil
+}
@@ -0,0 +1,209 @@
+package topology
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/noders-team/go-daml/pkg/crypto"
+	"github.com/noders-team/go-daml/pkg/model"
+	protov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/protocol/v30"
+)
+
+// topologyTransactionProtoVersion is the proto version Canton wraps
+// serialized topology transactions with.
+const topologyTransactionProtoVersion = 30
+
+// Signer signs topology transaction hashes with a key that never leaves the
+// caller, e.g. the root key of an external party's namespace.
+type Signer interface {
+	Fingerprint() string
+	PublicKey(usage ...model.SigningKeyUsage) model.PublicKey
+	Sign(hash []byte) (model.TopologyTransactionSignature, error)
+}
+
+type ed25519Signer struct {
+	privateKey  ed25519.PrivateKey
+	fingerprint string
+}
+
+// NewEd25519Signer creates a Signer from a base64 encoded Ed25519 private key,
+// as returned by crypto.CreateKeyPair.
+func NewEd25519Signer(privateKeyBase64 string) (*ed25519Signer, error) {
+	privateKeyBytes, err := base64.StdEncoding.DecodeString(privateKeyBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private key: %w", err)
+	}
+	if len(privateKeyBytes) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid private key size: expected %d, got %d", ed25519.PrivateKeySize, len(privateKeyBytes))
+	}
+
+	privateKey := ed25519.PrivateKey(privateKeyBytes)
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	fingerprint, err := crypto.CreateFingerprintFromKey(base64.StdEncoding.EncodeToString(publicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ed25519Signer{
+		privateKey:  privateKey,
+		fingerprint: fingerprint,
+	}, nil
+}
+
+func (s *ed25519Signer) Fingerprint() string {
+	return s.fingerprint
+}
+
+// PublicKey returns the public key in the form topology mappings expect.
+func (s *ed25519Signer) PublicKey(usage ...model.SigningKeyUsage) model.PublicKey {
+	usages := make([]int32, len(usage))
+	for i, u := range usage {
+		usages[i] = int32(u)
+	}
+	return model.PublicKey{
+		Format:  int32(model.CryptoKeyFormatRaw),
+		Key:     s.privateKey.Public().(ed25519.PublicKey),
+		ID:      s.fingerprint,
+		Scheme:  int32(model.SigningKeySchemeED25519),
+		KeySpec: int32(model.SigningKeySpecCurve25519),
+		Usage:   usages,
+	}
+}
+
+func (s *ed25519Signer) Sign(hash []byte) (model.TopologyTransactionSignature, error) {
+	return model.TopologyTransactionSignature{
+		SignedBy:             s.fingerprint,
+		Signature:            ed25519.Sign(s.privateKey, hash),
+		SignatureFormat:      int32(model.SignatureFormatConcat),
+		SigningAlgorithmSpec: model.SigningAlgorithmSpecED25519,
+	}, nil
+}
+
+// BuildTransaction serializes a topology transaction locally, byte for byte
+// what GenerateTransactions returns, together with the hash to sign.
+func BuildTransaction(op model.Operation, serial uint32, mapping model.TopologyMapping) (*model.GeneratedTransaction, error) {
+	pbMapping := topologyMappingToProto(mapping)
+	if pbMapping == nil || pbMapping.Mapping == nil {
+		return nil, fmt.Errorf("unsupported topology mapping %T", mapping)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(&protov30.TopologyTransaction{
+		Operation: operationToProto(op),
+		Serial:    serial,
+		Mapping:   pbMapping,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize topology transaction: %w", err)
+	}
+
+	// the UntypedVersionedMessage wrapper is encoded by hand: Go writes the
+	// data oneof after the version, while Canton writes fields in number order
+	serialized := protowire.AppendTag(nil, 1, protowire.BytesType)
+	serialized = protowire.AppendBytes(serialized, data)
+	serialized = protowire.AppendTag(serialized, 2, protowire.VarintType)
+	serialized = protowire.AppendVarint(serialized, topologyTransactionProtoVersion)
+
+	hash, err := TransactionHash(serialized)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GeneratedTransaction{
+		SerializedTransaction: serialized,
+		TransactionHash:       hash,
+	}, nil
+}
+
+// DecentralizedNamespace computes the namespace Canton derives from the
+// initial owners of a decentralized namespace definition.
+func DecentralizedNamespace(owners []string) (string, error) {
+	var data []byte
+	for _, owner := range slices.Sorted(slices.Values(owners)) {
+		data = binary.BigEndian.AppendUint32(data, uint32(len(owner)))
+		data = append(data, owner...)
+	}
+
+	hash, err := crypto.ComputeSHA256CantonHash(crypto.CantonHashPurposeDecentralizedNamespace, data)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash), nil
+}
+
+// TransactionHash computes the hash signers sign for a serialized topology
+// transaction.
+func TransactionHash(serialized []byte) ([]byte, error) {
+	return crypto.ComputeSHA256CantonHash(crypto.CantonHashPurposeTopologyTransaction, serialized)
+}
+
+// SignTransaction signs a single transaction with each signer.
+func SignTransaction(tx *model.GeneratedTransaction, signers ...Signer) (*model.SignedTopologyTransaction, error) {
+	signed := &model.SignedTopologyTransaction{Transaction: tx.SerializedTransaction}
+	if err := AddSignatures(signed, signers...); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// AddSignatures adds the signatures of further signers to a signed transaction,
+// e.g. when the owners of a decentralized namespace sign one after another.
+func AddSignatures(tx *model.SignedTopologyTransaction, signers ...Signer) error {
+	hash, err := TransactionHash(tx.Transaction)
+	if err != nil {
+		return err
+	}
+
+	for _, signer := range signers {
+		sig, err := signer.Sign(hash)
+		if err != nil {
+			return fmt.Errorf("failed to sign with %s: %w", signer.Fingerprint(), err)
+		}
+		tx.Signatures = append(tx.Signatures, sig)
+	}
+
+	return nil
+}
+
+// SignTransactions signs a batch of transactions once per signer: each signer
+// signs the combined hash of the batch, and the signature is attached to every
+// transaction as a multi-transaction signature.
+func SignTransactions(txs []*model.GeneratedTransaction, signers ...Signer) ([]*model.SignedTopologyTransaction, error) {
+	hashes := make([][]byte, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.TransactionHash
+	}
+
+	multiHash, err := crypto.ComputeMultiHashForTopology(hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]model.TopologyTransactionSignature, len(signers))
+	for i, signer := range signers {
+		signatures[i], err = signer.Sign(multiHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign with %s: %w", signer.Fingerprint(), err)
+		}
+	}
+
+	result := make([]*model.SignedTopologyTransaction, len(txs))
+	for i, tx := range txs {
+		result[i] = &model.SignedTopologyTransaction{
+			Transaction: tx.SerializedTransaction,
+			MultiTransactionSignatures: []*model.MultiTransactionSignatures{{
+				TransactionHashes: hashes,
+				Signatures:        signatures,
+			}},
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,138 @@
+package topology_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/noders-team/go-daml/pkg/crypto"
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+	"github.com/noders-team/go-daml/pkg/testutil"
+)
+
+func TestOfflineRootNamespaceDelegation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cl := testutil.GetClient()
+	require.NotNil(t, cl)
+
+	keyPair, err := crypto.CreateKeyPair()
+	require.NoError(t, err)
+	signer, err := topology.NewEd25519Signer(keyPair.PrivateKey)
+	require.NoError(t, err)
+
+	tx, err := topology.BuildTransaction(model.OperationAddReplace, 1, &model.NamespaceDelegationMapping{
+		Namespace:        signer.Fingerprint(),
+		TargetKey:        signer.PublicKey(model.SigningKeyUsageNamespace),
+		IsRootDelegation: true,
+	})
+	require.NoError(t, err)
+
+	signed, err := topology.SignTransaction(tx, signer)
+	require.NoError(t, err)
+
+	store := &model.StoreID{Value: "authorized"}
+	_, err = cl.TopologyManagerWrite.AddTransactions(ctx, &model.AddTransactionsRequest{
+		Transactions: []*model.SignedTopologyTransaction{signed},
+		Store:        store,
+	})
+	require.NoError(t, err)
+
+	resp, err := cl.TopologyManagerRead.ListNamespaceDelegation(ctx, &model.ListNamespaceDelegationRequest{
+		BaseQuery:       &model.BaseQuery{Store: store},
+		FilterNamespace: signer.Fingerprint(),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, signer.Fingerprint(), resp.Results[0].Item.Namespace)
+}
+
+func TestDecentralizedNamespace(t *testing.T) {
+	// owners are sorted and length prefixed before hashing with purpose 37
+	namespace, err := topology.DecentralizedNamespace([]string{"1220bbbb", "1220aaaa", "1220cc"})
+	require.NoError(t, err)
+	require.Equal(t, "12200ae1246e55c94d73fae6f1478dc4af0173ab8e2d034663c2b274dd7504c37b6f", namespace)
+
+	reordered, err := topology.DecentralizedNamespace([]string{"1220cc", "1220aaaa", "1220bbbb"})
+	require.NoError(t, err)
+	require.Equal(t, namespace, reordered)
+}
+
+func TestTransactionHash(t *testing.T) {
+	hash, err := topology.TransactionHash([]byte("serialized"))
+	require.NoError(t, err)
+	require.Equal(t, "1220e103bf9c116b559b20d9505949a78d65c0a024545767f970d6dd122e5844598b", hex.EncodeToString(hash))
+}
+
+func TestBuildTransaction(t *testing.T) {
+	tests := []struct {
+		serial     uint32
+		serialized string
+		hash       string
+	}{
+		{
+			serial:     1,
+			serialized: "0a26080110011a201a1e0a06313232306e7310021a0831323230616161611a083132323062626262101e",
+			hash:       "1220ab60e5e4fe7eb1b54a86784af216fcdc954b87f2f9f079b1b87513f5e7e71778",
+		},
+		{
+			serial:     2,
+			serialized: "0a26080110021a201a1e0a06313232306e7310021a0831323230616161611a083132323062626262101e",
+			hash:       "122038f2b07fc63bbe3c61018674a444909ccbba584f5988ca7aae65b34c9a9bef8f",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("serial %d", tt.serial), func(t *testing.T) {
+			tx, err := topology.BuildTransaction(model.OperationAddReplace, tt.serial, &model.DecentralizedNamespaceDefinitionMapping{
+				Namespace: "1220ns",
+				Threshold: 2,
+				Owners:    []string{"1220aaaa", "1220bbbb"},
+			})
+			require.NoError(t, err)
+			// the transaction is wrapped in an UntypedVersionedMessage with version 30
+			require.Equal(t, tt.serialized, hex.EncodeToString(tx.SerializedTransaction))
+			require.Equal(t, tt.hash, hex.EncodeToString(tx.TransactionHash))
+		})
+	}
+}
+
+func TestSignTransactions(t *testing.T) {
+	privateKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x01}, ed25519.SeedSize))
+	signer, err := topology.NewEd25519Signer(base64.StdEncoding.EncodeToString(privateKey))
+	require.NoError(t, err)
+
+	mapping := &model.DecentralizedNamespaceDefinitionMapping{
+		Namespace: "1220ns",
+		Threshold: 2,
+		Owners:    []string{"1220aaaa", "1220bbbb"},
+	}
+	first, err := topology.BuildTransaction(model.OperationAddReplace, 1, mapping)
+	require.NoError(t, err)
+	second, err := topology.BuildTransaction(model.OperationAddReplace, 2, mapping)
+	require.NoError(t, err)
+
+	signed, err := topology.SignTransactions([]*model.GeneratedTransaction{first, second}, signer)
+	require.NoError(t, err)
+	require.Len(t, signed, 2)
+
+	multiHash, err := hex.DecodeString("1220dbb7f406215260fa400a3d31c144d0e4d29fa25d190e83ee511c68db17628339")
+	require.NoError(t, err)
+	for _, tx := range signed {
+		require.Len(t, tx.MultiTransactionSignatures, 1)
+		multi := tx.MultiTransactionSignatures[0]
+		require.Equal(t, [][]byte{first.TransactionHash, second.TransactionHash}, multi.TransactionHashes)
+		require.Len(t, multi.Signatures, 1)
+		require.Equal(t, signer.Fingerprint(), multi.Signatures[0].SignedBy)
+		require.True(t, ed25519.Verify(privateKey.Public().(ed25519.PublicKey), multiHash, multi.Signatures[0].Signature))
+	}
+}
@@ -0,0 +1,94 @@
+package topology
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	topov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/topology/admin/v30"
+	versionv1 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/version/v1"
+)
+
+// ParseTopologySnapshot decodes the output of ExportTopologySnapshot and
+// GenesisState: a sequence of length-delimited, versioned stored topology
+// transactions.
+func ParseTopologySnapshot(data []byte) ([]*model.StoredTopologyTransaction, error) {
+	r := bufio.NewReader(bytes.NewReader(data))
+
+	var result []*model.StoredTopologyTransaction
+	for i := 0; ; i++ {
+		versioned := &versionv1.UntypedVersionedMessage{}
+		err := protodelim.UnmarshalFrom(r, versioned)
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read topology snapshot entry %d: %w", i, err)
+		}
+
+		item := &topov30.TopologyTransactions_Item{}
+		if err := proto.Unmarshal(versioned.GetData(), item); err != nil {
+			return nil, fmt.Errorf("failed to decode topology snapshot entry %d: %w", i, err)
+		}
+
+		stored, err := storedTopologyTransactionFromProto(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode topology transaction %d: %w", i, err)
+		}
+		result = append(result, stored)
+	}
+}
+
+// SignedTransactions returns the signed transactions of a parsed snapshot in
+// order, e.g. to replay them with AddTransactions on a node being bootstrapped.
+// Rejected transactions are skipped.
+func SignedTransactions(stored []*model.StoredTopologyTransaction) []*model.SignedTopologyTransaction {
+	result := make([]*model.SignedTopologyTransaction, 0, len(stored))
+	for _, s := range stored {
+		if s.RejectionReason != "" {
+			continue
+		}
+		result = append(result, s.Transaction)
+	}
+	return result
+}
+
+// DiffTopologySnapshots compares two parsed snapshots by transaction content,
+// ignoring signatures and validity, and returns the transactions only present
+// in b (added) and only present in a (removed).
+func DiffTopologySnapshots(a, b []*model.StoredTopologyTransaction) (added, removed []*model.StoredTopologyTransaction) {
+	inA := make(map[string]bool, len(a))
+	for _, s := range a {
+		inA[snapshotKey(s)] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[snapshotKey(s)] = true
+	}
+
+	for _, s := range b {
+		if !inA[snapshotKey(s)] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range a {
+		if !inB[snapshotKey(s)] {
+			removed = append(removed, s)
+		}
+	}
+
+	return added, removed
+}
+
+func snapshotKey(s *model.StoredTopologyTransaction) string {
+	if s.Transaction == nil {
+		return ""
+	}
+	return string(s.Transaction.Transaction)
+}
@@ -0,0 +1,110 @@
+package topology_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	"github.com/noders-team/go-daml/pkg/service/topology"
+	protov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/protocol/v30"
+	topov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/topology/admin/v30"
+	versionv1 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/version/v1"
+)
+
+func versioned(t *testing.T, msg proto.Message) *versionv1.UntypedVersionedMessage {
+	t.Helper()
+
+	data, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return &versionv1.UntypedVersionedMessage{
+		Wrapper: &versionv1.UntypedVersionedMessage_Data{Data: data},
+		Version: 30,
+	}
+}
+
+// snapshot writes the transactions as ExportTopologySnapshot does; a non-empty
+// rejection reason marks the transaction rejected.
+func snapshot(t *testing.T, validFrom time.Time, txs map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	for _, tx := range []string{"tx-a", "tx-b", "tx-c"} {
+		reason, ok := txs[tx]
+		if !ok {
+			continue
+		}
+
+		signed, err := proto.Marshal(versioned(t, &protov30.SignedTopologyTransaction{Transaction: []byte(tx)}))
+		require.NoError(t, err)
+		item := &topov30.TopologyTransactions_Item{
+			ValidFrom:   timestamppb.New(validFrom),
+			Transaction: signed,
+		}
+		if reason != "" {
+			item.RejectionReason = &reason
+		}
+
+		_, err = protodelim.MarshalTo(&buf, versioned(t, item))
+		require.NoError(t, err)
+	}
+	return buf.Bytes()
+}
+
+func transactions(stored []*model.SignedTopologyTransaction) []string {
+	result := make([]string, len(stored))
+	for i, s := range stored {
+		result[i] = string(s.Transaction)
+	}
+	return result
+}
+
+func TestParseTopologySnapshot(t *testing.T) {
+	validFrom := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := snapshot(t, validFrom, map[string]string{"tx-a": "", "tx-b": "not authorized", "tx-c": ""})
+
+	stored, err := topology.ParseTopologySnapshot(data)
+	require.NoError(t, err)
+	require.Len(t, stored, 3)
+	require.Equal(t, "tx-a", string(stored[0].Transaction.Transaction))
+	require.True(t, validFrom.Equal(*stored[0].ValidFrom))
+	require.Equal(t, "not authorized", stored[1].RejectionReason)
+
+	require.Equal(t, []string{"tx-a", "tx-c"}, transactions(topology.SignedTransactions(stored)))
+
+	empty, err := topology.ParseTopologySnapshot(nil)
+	require.NoError(t, err)
+	require.Empty(t, empty)
+
+	_, err = topology.ParseTopologySnapshot(data[:len(data)-3])
+	require.ErrorContains(t, err, "entry 2")
+}
+
+func TestDiffTopologySnapshots(t *testing.T) {
+	before, err := topology.ParseTopologySnapshot(snapshot(t, time.Unix(1, 0), map[string]string{"tx-a": "", "tx-b": ""}))
+	require.NoError(t, err)
+	// validity differs, which the diff ignores
+	after, err := topology.ParseTopologySnapshot(snapshot(t, time.Unix(2, 0), map[string]string{"tx-b": "", "tx-c": ""}))
+	require.NoError(t, err)
+
+	added, removed := topology.DiffTopologySnapshots(before, after)
+	require.Len(t, added, 1)
+	require.Equal(t, "tx-c", string(added[0].Transaction.Transaction))
+	require.Len(t, removed, 1)
+	require.Equal(t, "tx-a", string(removed[0].Transaction.Transaction))
+
+	added, removed = topology.DiffTopologySnapshots(after, before)
+	require.Len(t, added, 1)
+	require.Equal(t, "tx-a", string(added[0].Transaction.Transaction))
+	require.Len(t, removed, 1)
+	require.Equal(t, "tx-c", string(removed[0].Transaction.Transaction))
+
+	added, removed = topology.DiffTopologySnapshots(before, before)
+	require.Empty(t, added)
+	require.Empty(t, removed)
+}
@@ -0,0 +1,131 @@
+package topology
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/noders-team/go-daml/pkg/model"
+	topov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/topology/admin/v30"
+)
+
+// TopologyAggregation answers party hosting and key ownership questions across
+// all synchronizers a node is connected to, in a single call.
+type TopologyAggregation interface {
+	ListParties(ctx context.Context, req *model.ListPartiesRequest) (*model.ListPartiesResponse, error)
+	ListKeyOwners(ctx context.Context, req *model.ListKeyOwnersRequest) (*model.ListKeyOwnersResponse, error)
+}
+
+type topologyAggregation struct {
+	client topov30.TopologyAggregationServiceClient
+}
+
+func NewTopologyAggregationClient(conn *grpc.ClientConn) *topologyAggregation {
+	client := topov30.NewTopologyAggregationServiceClient(conn)
+	return &topologyAggregation{
+		client: client,
+	}
+}
+
+func (c *topologyAggregation) ListParties(ctx context.Context, req *model.ListPartiesRequest) (*model.ListPartiesResponse, error) {
+	protoReq := &topov30.ListPartiesRequest{
+		AsOf:              optionalTimestampToProto(req.AsOf),
+		Limit:             req.Limit,
+		SynchronizerIds:   req.SynchronizerIDs,
+		FilterParty:       req.FilterParty,
+		FilterParticipant: req.FilterParticipant,
+	}
+
+	resp, err := c.client.ListParties(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.PartyHosting, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = partyHostingFromProto(r)
+	}
+
+	return &model.ListPartiesResponse{Results: results}, nil
+}
+
+func (c *topologyAggregation) ListKeyOwners(ctx context.Context, req *model.ListKeyOwnersRequest) (*model.ListKeyOwnersResponse, error) {
+	protoReq := &topov30.ListKeyOwnersRequest{
+		AsOf:               optionalTimestampToProto(req.AsOf),
+		Limit:              req.Limit,
+		SynchronizerIds:    req.SynchronizerIDs,
+		FilterKeyOwnerType: req.FilterKeyOwnerType,
+		FilterKeyOwnerUid:  req.FilterKeyOwnerUID,
+	}
+
+	resp, err := c.client.ListKeyOwners(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.KeyOwnerKeys, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = keyOwnerKeysFromProto(r)
+	}
+
+	return &model.ListKeyOwnersResponse{Results: results}, nil
+}
+
+func optionalTimestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func partyHostingFromProto(pb *topov30.ListPartiesResponse_Result) *model.PartyHosting {
+	if pb == nil {
+		return nil
+	}
+
+	participants := make([]*model.PartyHostingParticipant, len(pb.Participants))
+	for i, p := range pb.Participants {
+		synchronizers := make([]*model.SynchronizerPermission, len(p.Synchronizers))
+		for j, s := range p.Synchronizers {
+			synchronizers[j] = &model.SynchronizerPermission{
+				SynchronizerID:         s.SynchronizerId,
+				PhysicalSynchronizerID: s.PhysicalSynchronizerId,
+				Permission:             participantPermissionFromProto(s.Permission),
+			}
+		}
+		participants[i] = &model.PartyHostingParticipant{
+			ParticipantUID: p.ParticipantUid,
+			Synchronizers:  synchronizers,
+		}
+	}
+
+	return &model.PartyHosting{
+		Party:        pb.Party,
+		Participants: participants,
+	}
+}
+
+func keyOwnerKeysFromProto(pb *topov30.ListKeyOwnersResponse_Result) *model.KeyOwnerKeys {
+	if pb == nil {
+		return nil
+	}
+
+	signingKeys := make([]model.PublicKey, len(pb.SigningKeys))
+	for i, k := range pb.SigningKeys {
+		signingKeys[i] = signingPublicKeyFromProto(k)
+	}
+	encryptionKeys := make([]model.PublicKey, len(pb.EncryptionKeys))
+	for i, k := range pb.EncryptionKeys {
+		encryptionKeys[i] = encryptionPublicKeyFromProto(k)
+	}
+
+	return &model.KeyOwnerKeys{
+		SynchronizerID:         pb.SynchronizerId,
+		PhysicalSynchronizerID: pb.PhysicalSynchronizerId,
+		KeyOwner:               pb.KeyOwner,
+		SigningKeys:            signingKeys,
+		EncryptionKeys:         encryptionKeys,
+	}
+}
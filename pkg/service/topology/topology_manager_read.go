@@ -2,15 +2,21 @@ package topology
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/noders-team/go-daml/pkg/model"
 	cryptov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/crypto/v30"
 	protov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/protocol/v30"
 	topov30 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/topology/admin/v30"
+	versionv1 "github.com/noders-team/go-daml/proto/com/digitalasset/canton/version/v1"
 )
 
 type TopologyManagerRead interface {
@@ -20,6 +26,22 @@ type TopologyManagerRead interface {
 	// PartyToParticipant mapping for externally signed parties.
 	ListPartyToKeyMapping(ctx context.Context, req *model.ListPartyToKeyMappingRequest) (*model.ListPartyToKeyMappingResponse, error)
 	ListPartyToParticipant(ctx context.Context, req *model.ListPartyToParticipantRequest) (*model.ListPartyToParticipantResponse, error)
+	ListDecentralizedNamespaceDefinition(ctx context.Context, req *model.ListDecentralizedNamespaceDefinitionRequest) (*model.ListDecentralizedNamespaceDefinitionResponse, error)
+	ListOwnerToKeyMapping(ctx context.Context, req *model.ListOwnerToKeyMappingRequest) (*model.ListOwnerToKeyMappingResponse, error)
+	ListSynchronizerTrustCertificate(ctx context.Context, req *model.ListSynchronizerTrustCertificateRequest) (*model.ListSynchronizerTrustCertificateResponse, error)
+	ListParticipantSynchronizerPermission(ctx context.Context, req *model.ListParticipantSynchronizerPermissionRequest) (*model.ListParticipantSynchronizerPermissionResponse, error)
+	ListPartyHostingLimits(ctx context.Context, req *model.ListPartyHostingLimitsRequest) (*model.ListPartyHostingLimitsResponse, error)
+	ListVettedPackages(ctx context.Context, req *model.ListTopologyVettedPackagesRequest) (*model.ListTopologyVettedPackagesResponse, error)
+	ListSynchronizerParametersState(ctx context.Context, req *model.ListSynchronizerParametersStateRequest) (*model.ListSynchronizerParametersStateResponse, error)
+	ListSequencingParametersState(ctx context.Context, req *model.ListSequencingParametersStateRequest) (*model.ListSequencingParametersStateResponse, error)
+	ListMediatorSynchronizerState(ctx context.Context, req *model.ListMediatorSynchronizerStateRequest) (*model.ListMediatorSynchronizerStateResponse, error)
+	ListSequencerSynchronizerState(ctx context.Context, req *model.ListSequencerSynchronizerStateRequest) (*model.ListSequencerSynchronizerStateResponse, error)
+	ListAvailableStores(ctx context.Context) ([]*model.StoreID, error)
+	// ListAll returns every topology transaction in the queried store, e.g. for
+	// a full topology inventory.
+	ListAll(ctx context.Context, req *model.ListAllRequest) (*model.ListAllResponse, error)
+	ExportTopologySnapshot(ctx context.Context, req *model.ExportTopologySnapshotRequest, w io.Writer) error
+	GenesisState(ctx context.Context, req *model.GenesisStateRequest, w io.Writer) error
 }
 
 type topologyManagerRead struct {
@@ -69,6 +91,341 @@ func (c *topologyManagerRead) ListPartyToParticipant(ctx context.Context, req *m
 	return listPartyToParticipantResponseFromProto(resp), nil
 }
 
+func (c *topologyManagerRead) ListDecentralizedNamespaceDefinition(ctx context.Context, req *model.ListDecentralizedNamespaceDefinitionRequest) (*model.ListDecentralizedNamespaceDefinitionResponse, error) {
+	protoReq := &topov30.ListDecentralizedNamespaceDefinitionRequest{
+		BaseQuery:       baseQueryToProto(req.BaseQuery),
+		FilterNamespace: req.FilterNamespace,
+	}
+
+	resp, err := c.client.ListDecentralizedNamespaceDefinition(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.DecentralizedNamespaceDefinitionResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = &model.DecentralizedNamespaceDefinitionResult{
+			Context: baseResultFromProto(r.Context),
+			Item:    decentralizedNamespaceDefinitionFromProto(r.Item),
+		}
+	}
+
+	return &model.ListDecentralizedNamespaceDefinitionResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListOwnerToKeyMapping(ctx context.Context, req *model.ListOwnerToKeyMappingRequest) (*model.ListOwnerToKeyMappingResponse, error) {
+	protoReq := &topov30.ListOwnerToKeyMappingRequest{
+		BaseQuery:          baseQueryToProto(req.BaseQuery),
+		FilterKeyOwnerType: req.FilterKeyOwnerType,
+		FilterKeyOwnerUid:  req.FilterKeyOwnerUID,
+	}
+
+	resp, err := c.client.ListOwnerToKeyMapping(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.OwnerToKeyMappingResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = &model.OwnerToKeyMappingResult{
+			Context: baseResultFromProto(r.Context),
+			Item:    ownerToKeyMappingFromProto(r.Item),
+		}
+	}
+
+	return &model.ListOwnerToKeyMappingResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListSynchronizerTrustCertificate(ctx context.Context, req *model.ListSynchronizerTrustCertificateRequest) (*model.ListSynchronizerTrustCertificateResponse, error) {
+	protoReq := &topov30.ListSynchronizerTrustCertificateRequest{
+		BaseQuery: baseQueryToProto(req.BaseQuery),
+		FilterUid: req.FilterUID,
+	}
+
+	resp, err := c.client.ListSynchronizerTrustCertificate(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.SynchronizerTrustCertificateResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = &model.SynchronizerTrustCertificateResult{
+			Context: baseResultFromProto(r.Context),
+			Item:    synchronizerTrustCertificateFromProto(r.Item),
+		}
+	}
+
+	return &model.ListSynchronizerTrustCertificateResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListParticipantSynchronizerPermission(ctx context.Context, req *model.ListParticipantSynchronizerPermissionRequest) (*model.ListParticipantSynchronizerPermissionResponse, error) {
+	protoReq := &topov30.ListParticipantSynchronizerPermissionRequest{
+		BaseQuery: baseQueryToProto(req.BaseQuery),
+		FilterUid: req.FilterUID,
+	}
+
+	resp, err := c.client.ListParticipantSynchronizerPermission(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.ParticipantSynchronizerPermissionResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = &model.ParticipantSynchronizerPermissionResult{
+			Context: baseResultFromProto(r.Context),
+			Item:    participantSynchronizerPermissionFromProto(r.Item),
+		}
+	}
+
+	return &model.ListParticipantSynchronizerPermissionResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListPartyHostingLimits(ctx context.Context, req *model.ListPartyHostingLimitsRequest) (*model.ListPartyHostingLimitsResponse, error) {
+	protoReq := &topov30.ListPartyHostingLimitsRequest{
+		BaseQuery: baseQueryToProto(req.BaseQuery),
+		FilterUid: req.FilterUID,
+	}
+
+	resp, err := c.client.ListPartyHostingLimits(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.PartyHostingLimitsResult, len(resp.Results))
+	for i, r := range resp.Results {
+		result := &model.PartyHostingLimitsResult{
+			Context: baseResultFromProto(r.Context),
+		}
+		if r.Item != nil {
+			result.Item = &model.PartyHostingLimitsMapping{
+				SynchronizerID: r.Item.SynchronizerId,
+				Party:          r.Item.Party,
+			}
+		}
+		results[i] = result
+	}
+
+	return &model.ListPartyHostingLimitsResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListVettedPackages(ctx context.Context, req *model.ListTopologyVettedPackagesRequest) (*model.ListTopologyVettedPackagesResponse, error) {
+	protoReq := &topov30.ListVettedPackagesRequest{
+		BaseQuery:         baseQueryToProto(req.BaseQuery),
+		FilterParticipant: req.FilterParticipant,
+	}
+
+	resp, err := c.client.ListVettedPackages(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.VettedPackagesResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = &model.VettedPackagesResult{
+			Context: baseResultFromProto(r.Context),
+			Item:    vettedPackagesFromProto(r.Item),
+		}
+	}
+
+	return &model.ListTopologyVettedPackagesResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListSynchronizerParametersState(ctx context.Context, req *model.ListSynchronizerParametersStateRequest) (*model.ListSynchronizerParametersStateResponse, error) {
+	protoReq := &topov30.ListSynchronizerParametersStateRequest{
+		BaseQuery:            baseQueryToProto(req.BaseQuery),
+		FilterSynchronizerId: req.FilterSynchronizerID,
+	}
+
+	resp, err := c.client.ListSynchronizerParametersState(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.SynchronizerParametersStateResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = &model.SynchronizerParametersStateResult{
+			Context: baseResultFromProto(r.Context),
+			Item:    synchronizerParametersFromProto(r.Item),
+		}
+	}
+
+	return &model.ListSynchronizerParametersStateResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListSequencingParametersState(ctx context.Context, req *model.ListSequencingParametersStateRequest) (*model.ListSequencingParametersStateResponse, error) {
+	protoReq := &topov30.ListSequencingParametersStateRequest{
+		BaseQuery:            baseQueryToProto(req.BaseQuery),
+		FilterSynchronizerId: req.FilterSynchronizerID,
+	}
+
+	resp, err := c.client.ListSequencingParametersState(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.SequencingParametersStateResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = &model.SequencingParametersStateResult{
+			Context: baseResultFromProto(r.Context),
+			Payload: r.Item.GetPayload(),
+		}
+	}
+
+	return &model.ListSequencingParametersStateResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListMediatorSynchronizerState(ctx context.Context, req *model.ListMediatorSynchronizerStateRequest) (*model.ListMediatorSynchronizerStateResponse, error) {
+	protoReq := &topov30.ListMediatorSynchronizerStateRequest{
+		BaseQuery:            baseQueryToProto(req.BaseQuery),
+		FilterSynchronizerId: req.FilterSynchronizerID,
+	}
+
+	resp, err := c.client.ListMediatorSynchronizerState(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.MediatorSynchronizerStateResult, len(resp.Results))
+	for i, r := range resp.Results {
+		result := &model.MediatorSynchronizerStateResult{
+			Context: baseResultFromProto(r.Context),
+		}
+		if r.Item != nil {
+			result.Item = &model.MediatorSynchronizerStateMapping{
+				SynchronizerID: r.Item.SynchronizerId,
+				Group:          r.Item.Group,
+				Threshold:      r.Item.Threshold,
+				Active:         r.Item.Active,
+				Observers:      r.Item.Observers,
+			}
+		}
+		results[i] = result
+	}
+
+	return &model.ListMediatorSynchronizerStateResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListSequencerSynchronizerState(ctx context.Context, req *model.ListSequencerSynchronizerStateRequest) (*model.ListSequencerSynchronizerStateResponse, error) {
+	protoReq := &topov30.ListSequencerSynchronizerStateRequest{
+		BaseQuery:            baseQueryToProto(req.BaseQuery),
+		FilterSynchronizerId: req.FilterSynchronizerID,
+	}
+
+	resp, err := c.client.ListSequencerSynchronizerState(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.SequencerSynchronizerStateResult, len(resp.Results))
+	for i, r := range resp.Results {
+		result := &model.SequencerSynchronizerStateResult{
+			Context: baseResultFromProto(r.Context),
+		}
+		if r.Item != nil {
+			result.Item = &model.SequencerSynchronizerStateMapping{
+				SynchronizerID: r.Item.SynchronizerId,
+				Threshold:      r.Item.Threshold,
+				Active:         r.Item.Active,
+				Observers:      r.Item.Observers,
+			}
+		}
+		results[i] = result
+	}
+
+	return &model.ListSequencerSynchronizerStateResponse{Results: results}, nil
+}
+
+func (c *topologyManagerRead) ListAvailableStores(ctx context.Context) ([]*model.StoreID, error) {
+	resp, err := c.client.ListAvailableStores(ctx, &topov30.ListAvailableStoresRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	stores := make([]*model.StoreID, len(resp.StoreIds))
+	for i, store := range resp.StoreIds {
+		stores[i] = storeIDFromProto(store)
+	}
+
+	return stores, nil
+}
+
+func (c *topologyManagerRead) ListAll(ctx context.Context, req *model.ListAllRequest) (*model.ListAllResponse, error) {
+	protoReq := &topov30.ListAllRequest{
+		BaseQuery:       baseQueryToProto(req.BaseQuery),
+		ExcludeMappings: req.ExcludeMappings,
+		FilterNamespace: req.FilterNamespace,
+	}
+
+	resp, err := c.client.ListAll(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := storedTopologyTransactionsFromProto(resp.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ListAllResponse{Transactions: txs}, nil
+}
+
+// ExportTopologySnapshot writes the matching topology transactions to w. Use
+// ParseTopologySnapshot to decode the result.
+func (c *topologyManagerRead) ExportTopologySnapshot(ctx context.Context, req *model.ExportTopologySnapshotRequest, w io.Writer) error {
+	protoReq := &topov30.ExportTopologySnapshotV2Request{
+		BaseQuery:       baseQueryToProto(req.BaseQuery),
+		ExcludeMappings: req.ExcludeMappings,
+		FilterNamespace: req.FilterNamespace,
+	}
+
+	stream, err := c.client.ExportTopologySnapshotV2(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(resp.Chunk); err != nil {
+			return fmt.Errorf("failed to write topology snapshot chunk: %w", err)
+		}
+	}
+}
+
+// GenesisState writes the topology state a new sequencer of the synchronizer
+// is initialized with to w.
+func (c *topologyManagerRead) GenesisState(ctx context.Context, req *model.GenesisStateRequest, w io.Writer) error {
+	protoReq := &topov30.GenesisStateV2Request{
+		SynchronizerStore: storeIDToProto(req.SynchronizerStore),
+		Timestamp:         optionalTimestampToProto(req.Timestamp),
+	}
+
+	stream, err := c.client.GenesisStateV2(ctx, protoReq)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(resp.Chunk); err != nil {
+			return fmt.Errorf("failed to write genesis state chunk: %w", err)
+		}
+	}
+}
+
 func listNamespaceDelegationRequestToProto(req *model.ListNamespaceDelegationRequest) *topov30.ListNamespaceDelegationRequest {
 	if req == nil {
 		return nil
@@ -165,7 +522,12 @@ func baseQueryToProto(query *model.BaseQuery) *topov30.BaseQuery {
 		pbQuery.ProtocolVersion = query.ProtocolVersion
 	}
 
-	if query.TimeQuery != nil {
+	// Canton rejects a BaseQuery without time_query, so no TimeQuery reads the head state
+	if query.TimeQuery == nil {
+		pbQuery.TimeQuery = &topov30.BaseQuery_HeadState{
+			HeadState: &emptypb.Empty{},
+		}
+	} else {
 		if query.TimeQuery.Serial != nil {
 			pbQuery.TimeQuery = &topov30.BaseQuery_Snapshot{
 				Snapshot: timestamppb.New(time.Unix(*query.TimeQuery.Serial, 0)),
@@ -334,6 +696,7 @@ func partyToParticipantMappingFromProto(pb *protov30.PartyToParticipant) *model.
 		participants[i] = model.HostingParticipant{
 			ParticipantUID: p.ParticipantUid,
 			Permission:     participantPermissionFromProto(p.Permission),
+			Onboarding:     p.Onboarding != nil,
 		}
 	}
 
@@ -356,12 +719,223 @@ func participantPermissionFromProto(pp protov30.Enums_ParticipantPermission) mod
 }
 
 func signingPublicKeyFromProto(pb *cryptov30.SigningPublicKey) model.PublicKey {
+	if pb == nil {
+		return model.PublicKey{}
+	}
+	usage := make([]int32, len(pb.Usage))
+	for i, u := range pb.Usage {
+		usage[i] = int32(u)
+	}
+	return model.PublicKey{
+		Format:  int32(pb.Format),
+		Key:     pb.PublicKey,
+		ID:      "",
+		KeySpec: int32(pb.KeySpec),
+		Usage:   usage,
+	}
+}
+
+func encryptionPublicKeyFromProto(pb *cryptov30.EncryptionPublicKey) model.PublicKey {
 	if pb == nil {
 		return model.PublicKey{}
 	}
 	return model.PublicKey{
-		Format: int32(pb.Format),
-		Key:    pb.PublicKey,
-		ID:     "",
+		Format:  int32(pb.Format),
+		Key:     pb.PublicKey,
+		KeySpec: int32(pb.KeySpec),
+	}
+}
+
+func durationFromProto(pb *durationpb.Duration) time.Duration {
+	if pb == nil {
+		return 0
+	}
+	return pb.AsDuration()
+}
+
+func optionalTimeFromProto(pb *timestamppb.Timestamp) *time.Time {
+	if pb == nil {
+		return nil
+	}
+	t := pb.AsTime()
+	return &t
+}
+
+func decentralizedNamespaceDefinitionFromProto(pb *protov30.DecentralizedNamespaceDefinition) *model.DecentralizedNamespaceDefinitionMapping {
+	if pb == nil {
+		return nil
+	}
+
+	return &model.DecentralizedNamespaceDefinitionMapping{
+		Namespace: pb.DecentralizedNamespace,
+		Threshold: pb.Threshold,
+		Owners:    pb.Owners,
+	}
+}
+
+func ownerToKeyMappingFromProto(pb *protov30.OwnerToKeyMapping) *model.OwnerToKeyMapping {
+	if pb == nil {
+		return nil
+	}
+
+	mapping := &model.OwnerToKeyMapping{
+		Member: pb.Member,
 	}
+	for _, key := range pb.PublicKeys {
+		switch k := key.GetKey().(type) {
+		case *cryptov30.PublicKey_SigningPublicKey:
+			mapping.SigningKeys = append(mapping.SigningKeys, signingPublicKeyFromProto(k.SigningPublicKey))
+		case *cryptov30.PublicKey_EncryptionPublicKey:
+			mapping.EncryptionKeys = append(mapping.EncryptionKeys, encryptionPublicKeyFromProto(k.EncryptionPublicKey))
+		}
+	}
+
+	return mapping
+}
+
+func synchronizerTrustCertificateFromProto(pb *protov30.SynchronizerTrustCertificate) *model.SynchronizerTrustCertificateMapping {
+	if pb == nil {
+		return nil
+	}
+
+	flags := make([]model.ParticipantFeatureFlag, len(pb.FeatureFlags))
+	for i, f := range pb.FeatureFlags {
+		flags[i] = model.ParticipantFeatureFlag(f)
+	}
+
+	return &model.SynchronizerTrustCertificateMapping{
+		ParticipantUID: pb.ParticipantUid,
+		SynchronizerID: pb.SynchronizerId,
+		FeatureFlags:   flags,
+	}
+}
+
+func participantSynchronizerPermissionFromProto(pb *protov30.ParticipantSynchronizerPermission) *model.ParticipantSynchronizerPermissionMapping {
+	if pb == nil {
+		return nil
+	}
+
+	mapping := &model.ParticipantSynchronizerPermissionMapping{
+		SynchronizerID: pb.SynchronizerId,
+		ParticipantUID: pb.ParticipantUid,
+		Permission:     participantPermissionFromProto(pb.Permission),
+		LoginAfter:     pb.LoginAfter,
+	}
+	if pb.Limits != nil {
+		rate := pb.Limits.ConfirmationRequestsMaxRate
+		mapping.ConfirmationRequestsMaxRate = &rate
+	}
+
+	return mapping
+}
+
+func vettedPackagesFromProto(pb *protov30.VettedPackages) *model.VettedPackagesMapping {
+	if pb == nil {
+		return nil
+	}
+
+	packages := make([]*model.TopologyVettedPackage, 0, len(pb.Packages)+len(pb.PackageIds))
+	for _, p := range pb.Packages {
+		packages = append(packages, &model.TopologyVettedPackage{
+			PackageID:           p.PackageId,
+			ValidFromInclusive:  optionalTimeFromProto(p.ValidFromInclusive),
+			ValidUntilExclusive: optionalTimeFromProto(p.ValidUntilExclusive),
+		})
+	}
+	// Older protocol versions only carry unbounded package IDs.
+	for _, id := range pb.PackageIds {
+		packages = append(packages, &model.TopologyVettedPackage{PackageID: id})
+	}
+
+	return &model.VettedPackagesMapping{
+		ParticipantUID: pb.ParticipantUid,
+		Packages:       packages,
+	}
+}
+
+func synchronizerParametersFromProto(pb *protov30.DynamicSynchronizerParameters) *model.SynchronizerParameters {
+	if pb == nil {
+		return nil
+	}
+
+	params := &model.SynchronizerParameters{
+		ConfirmationResponseTimeout:         durationFromProto(pb.ConfirmationResponseTimeout),
+		MediatorReactionTimeout:             durationFromProto(pb.MediatorReactionTimeout),
+		AssignmentExclusivityTimeout:        durationFromProto(pb.AssignmentExclusivityTimeout),
+		LedgerTimeRecordTimeTolerance:       durationFromProto(pb.LedgerTimeRecordTimeTolerance),
+		ReconciliationInterval:              durationFromProto(pb.ReconciliationInterval),
+		MediatorDeduplicationTimeout:        durationFromProto(pb.MediatorDeduplicationTimeout),
+		SequencerAggregateSubmissionTimeout: durationFromProto(pb.SequencerAggregateSubmissionTimeout),
+		PreparationTimeRecordTimeTolerance:  durationFromProto(pb.PreparationTimeRecordTimeTolerance),
+		MaxRequestSize:                      pb.MaxRequestSize,
+		OnboardingRestriction:               model.OnboardingRestriction(pb.OnboardingRestriction),
+		ConfirmationRequestsMaxRate:         pb.ParticipantSynchronizerLimits.GetConfirmationRequestsMaxRate(),
+	}
+	if tc := pb.TrafficControl; tc != nil {
+		params.TrafficControl = &model.TrafficControlParameters{
+			MaxBaseTrafficAmount:                  tc.MaxBaseTrafficAmount,
+			MaxBaseTrafficAccumulationDuration:    durationFromProto(tc.MaxBaseTrafficAccumulationDuration),
+			ReadVsWriteScalingFactor:              tc.ReadVsWriteScalingFactor,
+			SetBalanceRequestSubmissionWindowSize: durationFromProto(tc.SetBalanceRequestSubmissionWindowSize),
+			EnforceRateLimiting:                   tc.EnforceRateLimiting,
+			BaseEventCost:                         tc.BaseEventCost,
+			FreeConfirmationResponses:             tc.FreeConfirmationResponses,
+		}
+	}
+	if catchUp := pb.AcsCommitmentsCatchup; catchUp != nil {
+		params.AcsCommitmentsCatchUp = &model.AcsCommitmentsCatchUpConfig{
+			CatchUpIntervalSkip:         catchUp.CatchupIntervalSkip,
+			NrIntervalsToTriggerCatchUp: catchUp.NrIntervalsToTriggerCatchup,
+		}
+	}
+
+	return params
+}
+
+func storedTopologyTransactionsFromProto(pb *topov30.TopologyTransactions) ([]*model.StoredTopologyTransaction, error) {
+	if pb == nil {
+		return nil, nil
+	}
+
+	result := make([]*model.StoredTopologyTransaction, len(pb.Items))
+	for i, item := range pb.Items {
+		stored, err := storedTopologyTransactionFromProto(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode topology transaction %d: %w", i, err)
+		}
+		result[i] = stored
+	}
+
+	return result, nil
+}
+
+func storedTopologyTransactionFromProto(pb *topov30.TopologyTransactions_Item) (*model.StoredTopologyTransaction, error) {
+	tx, err := decodeSignedTopologyTransaction(pb.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.StoredTopologyTransaction{
+		Sequenced:       optionalTimeFromProto(pb.Sequenced),
+		ValidFrom:       optionalTimeFromProto(pb.ValidFrom),
+		ValidUntil:      optionalTimeFromProto(pb.ValidUntil),
+		Transaction:     tx,
+		RejectionReason: pb.GetRejectionReason(),
+	}, nil
+}
+
+// decodeSignedTopologyTransaction decodes a signed topology transaction
+// serialized with its protocol version wrapper.
+func decodeSignedTopologyTransaction(data []byte) (*model.SignedTopologyTransaction, error) {
+	versioned := &versionv1.UntypedVersionedMessage{}
+	if err := proto.Unmarshal(data, versioned); err != nil {
+		return nil, err
+	}
+
+	tx := &protov30.SignedTopologyTransaction{}
+	if err := proto.Unmarshal(versioned.GetData(), tx); err != nil {
+		return nil, err
+	}
+
+	return signedTopologyTransactionFromProto(tx), nil
 }
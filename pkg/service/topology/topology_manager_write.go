@@ -3,6 +3,7 @@ package topology
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 
 	"google.golang.org/grpc"
@@ -21,6 +22,7 @@ type TopologyManagerWrite interface {
 	GenerateTransactions(ctx context.Context, req *model.GenerateTransactionsRequest) (*model.GenerateTransactionsResponse, error)
 	CreateTemporaryTopologyStore(ctx context.Context, req *model.CreateTemporaryTopologyStoreRequest) (*model.CreateTemporaryTopologyStoreResponse, error)
 	DropTemporaryTopologyStore(ctx context.Context, req *model.DropTemporaryTopologyStoreRequest) (*model.DropTemporaryTopologyStoreResponse, error)
+	ImportTopologySnapshot(ctx context.Context, req *model.ImportTopologySnapshotRequest) (*model.ImportTopologySnapshotResponse, error)
 }
 
 type topologyManagerWrite struct {
@@ -181,9 +183,10 @@ func signedTopologyTransactionToProto(tx *model.SignedTopologyTransaction) *prot
 	signatures := make([]*cryptov30.Signature, len(tx.Signatures))
 	for i, sig := range tx.Signatures {
 		signatures[i] = &cryptov30.Signature{
-			SignedBy:  sig.SignedBy,
-			Signature: sig.Signature,
-			Format:    cryptov30.SignatureFormat(sig.SignatureFormat),
+			SignedBy:             sig.SignedBy,
+			Signature:            sig.Signature,
+			Format:               cryptov30.SignatureFormat(sig.SignatureFormat),
+			SigningAlgorithmSpec: cryptov30.SigningAlgorithmSpec(sig.SigningAlgorithmSpec),
 		}
 	}
 
@@ -192,9 +195,10 @@ func signedTopologyTransactionToProto(tx *model.SignedTopologyTransaction) *prot
 		sigs := make([]*cryptov30.Signature, len(mts.Signatures))
 		for j, sig := range mts.Signatures {
 			sigs[j] = &cryptov30.Signature{
-				SignedBy:  sig.SignedBy,
-				Signature: sig.Signature,
-				Format:    cryptov30.SignatureFormat(sig.SignatureFormat),
+				SignedBy:             sig.SignedBy,
+				Signature:            sig.Signature,
+				Format:               cryptov30.SignatureFormat(sig.SignatureFormat),
+				SigningAlgorithmSpec: cryptov30.SigningAlgorithmSpec(sig.SigningAlgorithmSpec),
 			}
 		}
 		multiTxSigs[i] = &protov30.MultiTransactionSignatures{
@@ -227,9 +231,10 @@ func signedTopologyTransactionFromProto(pb *protov30.SignedTopologyTransaction)
 	signatures := make([]model.TopologyTransactionSignature, len(pb.Signatures))
 	for i, sig := range pb.Signatures {
 		signatures[i] = model.TopologyTransactionSignature{
-			SignedBy:        sig.SignedBy,
-			Signature:       sig.Signature,
-			SignatureFormat: int32(sig.Format),
+			SignedBy:             sig.SignedBy,
+			Signature:            sig.Signature,
+			SignatureFormat:      int32(sig.Format),
+			SigningAlgorithmSpec: model.SigningAlgorithmSpec(sig.SigningAlgorithmSpec),
 		}
 	}
 
@@ -238,9 +243,10 @@ func signedTopologyTransactionFromProto(pb *protov30.SignedTopologyTransaction)
 		sigs := make([]model.TopologyTransactionSignature, len(mts.Signatures))
 		for j, sig := range mts.Signatures {
 			sigs[j] = model.TopologyTransactionSignature{
-				SignedBy:        sig.SignedBy,
-				Signature:       sig.Signature,
-				SignatureFormat: int32(sig.Format),
+				SignedBy:             sig.SignedBy,
+				Signature:            sig.Signature,
+				SignatureFormat:      int32(sig.Format),
+				SigningAlgorithmSpec: model.SigningAlgorithmSpec(sig.SigningAlgorithmSpec),
 			}
 		}
 		multiTxSigs[i] = &model.MultiTransactionSignatures{
@@ -300,6 +306,9 @@ func topologyMappingToProto(mapping model.TopologyMapping) *protov30.TopologyMap
 				ParticipantUid: p.ParticipantUID,
 				Permission:     participantPermissionToProto(p.Permission),
 			}
+			if p.Onboarding {
+				participants[i].Onboarding = &protov30.PartyToParticipant_HostingParticipant_Onboarding{}
+			}
 		}
 		ptp := &protov30.PartyToParticipant{
 			Party:        m.Party,
@@ -323,6 +332,66 @@ func topologyMappingToProto(mapping model.TopologyMapping) *protov30.TopologyMap
 		pbMapping.Mapping = &protov30.TopologyMapping_PartyToParticipant{
 			PartyToParticipant: ptp,
 		}
+	case *model.DecentralizedNamespaceDefinitionMapping:
+		pbMapping.Mapping = &protov30.TopologyMapping_DecentralizedNamespaceDefinition{
+			DecentralizedNamespaceDefinition: &protov30.DecentralizedNamespaceDefinition{
+				DecentralizedNamespace: m.Namespace,
+				Threshold:              m.Threshold,
+				Owners:                 m.Owners,
+			},
+		}
+	case *model.OwnerToKeyMapping:
+		keys := make([]*cryptov30.PublicKey, 0, len(m.SigningKeys)+len(m.EncryptionKeys))
+		for i := range m.SigningKeys {
+			keys = append(keys, &cryptov30.PublicKey{
+				Key: &cryptov30.PublicKey_SigningPublicKey{SigningPublicKey: signingPublicKeyToProto(&m.SigningKeys[i])},
+			})
+		}
+		for i := range m.EncryptionKeys {
+			keys = append(keys, &cryptov30.PublicKey{
+				Key: &cryptov30.PublicKey_EncryptionPublicKey{EncryptionPublicKey: encryptionPublicKeyToProto(&m.EncryptionKeys[i])},
+			})
+		}
+		pbMapping.Mapping = &protov30.TopologyMapping_OwnerToKeyMapping{
+			OwnerToKeyMapping: &protov30.OwnerToKeyMapping{
+				Member:     m.Member,
+				PublicKeys: keys,
+			},
+		}
+	case *model.SynchronizerTrustCertificateMapping:
+		flags := make([]protov30.Enums_ParticipantFeatureFlag, len(m.FeatureFlags))
+		for i, f := range m.FeatureFlags {
+			flags[i] = protov30.Enums_ParticipantFeatureFlag(f)
+		}
+		pbMapping.Mapping = &protov30.TopologyMapping_SynchronizerTrustCertificate{
+			SynchronizerTrustCertificate: &protov30.SynchronizerTrustCertificate{
+				ParticipantUid: m.ParticipantUID,
+				SynchronizerId: m.SynchronizerID,
+				FeatureFlags:   flags,
+			},
+		}
+	case *model.PartyHostingLimitsMapping:
+		pbMapping.Mapping = &protov30.TopologyMapping_PartyHostingLimits{
+			PartyHostingLimits: &protov30.PartyHostingLimits{
+				SynchronizerId: m.SynchronizerID,
+				Party:          m.Party,
+			},
+		}
+	case *model.VettedPackagesMapping:
+		packages := make([]*protov30.VettedPackages_VettedPackage, len(m.Packages))
+		for i, p := range m.Packages {
+			packages[i] = &protov30.VettedPackages_VettedPackage{
+				PackageId:           p.PackageID,
+				ValidFromInclusive:  optionalTimestampToProto(p.ValidFromInclusive),
+				ValidUntilExclusive: optionalTimestampToProto(p.ValidUntilExclusive),
+			}
+		}
+		pbMapping.Mapping = &protov30.TopologyMapping_VettedPackages{
+			VettedPackages: &protov30.VettedPackages{
+				ParticipantUid: m.ParticipantUID,
+				Packages:       packages,
+			},
+		}
 	}
 
 	return pbMapping
@@ -345,6 +414,17 @@ func signingPublicKeyToProto(key *model.PublicKey) *cryptov30.SigningPublicKey {
 	}
 }
 
+func encryptionPublicKeyToProto(key *model.PublicKey) *cryptov30.EncryptionPublicKey {
+	if key == nil {
+		return nil
+	}
+	return &cryptov30.EncryptionPublicKey{
+		Format:    cryptov30.CryptoKeyFormat(key.Format),
+		PublicKey: key.Key,
+		KeySpec:   cryptov30.EncryptionKeySpec(key.KeySpec),
+	}
+}
+
 func participantPermissionToProto(permission model.ParticipantPermission) protov30.Enums_ParticipantPermission {
 	switch permission {
 	case model.ParticipantPermissionConfirmation:
@@ -415,6 +495,46 @@ func (c *topologyManagerWrite) DropTemporaryTopologyStore(ctx context.Context, r
 	return &model.DropTemporaryTopologyStoreResponse{}, nil
 }
 
+const topologySnapshotChunkSize = 1 << 20
+
+// ImportTopologySnapshot streams a snapshot produced by ExportTopologySnapshot
+// or GenesisState into a topology store.
+func (c *topologyManagerWrite) ImportTopologySnapshot(ctx context.Context, req *model.ImportTopologySnapshotRequest) (*model.ImportTopologySnapshotResponse, error) {
+	stream, err := c.client.ImportTopologySnapshotV2(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := req.TopologySnapshot
+	for first := true; first || len(data) > 0; first = false {
+		n := min(len(data), topologySnapshotChunkSize)
+		chunk := &topov30.ImportTopologySnapshotV2Request{
+			TopologySnapshot: data[:n],
+		}
+		if first {
+			chunk.Store = storeIDToProto(req.Store)
+			if req.WaitToBecomeEffective != nil {
+				chunk.WaitToBecomeEffective = durationpb.New(*req.WaitToBecomeEffective)
+			}
+		}
+		data = data[n:]
+
+		if err := stream.Send(chunk); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ImportTopologySnapshotResponse{}, nil
+}
+
 func signTransactionsRequestToProto(req *model.SignTransactionsRequest) *topov30.SignTransactionsRequest {
 	if req == nil {
 		return nil
@@ -21,9 +21,13 @@ import (
 
 const (
 	damlSandboxVersion  = "3.5.0-snapshot.20251106.0"
-	containerName       = "go-daml-test-canton"
+	containerName       = "go-daml-test-canton-auth"
 	containerLabelKey   = "go-daml-test"
 	containerLabelValue = "canton-sandbox"
+	// jwtSecret signs the Ledger API tokens of the sandbox, which runs with
+	// unsafe-jwt-hmac-256 authorization.
+	jwtSecret = "go-daml-test-secret"
+	adminUser = "participant_admin"
 )
 
 var (
@@ -52,15 +56,7 @@ func Setup(ctx context.Context) error {
 
 		resDaml, grpcAddr, adminAddr = initDamlSandbox(ctx, dockerPool)
 
-		builder := client.NewDamlClient(grpcAddr, auth.NewBearerTokenProvider("")).
-			WithAdminAddress(adminAddr).
-			WithAdminTokenProvider(auth.NewNoAuth())
-		if strings.HasSuffix(grpcAddr, ":443") {
-			tlsConfig := client.TlsConfig{}
-			builder = builder.WithTLSConfig(tlsConfig)
-		}
-
-		cl, err = builder.Build(context.Background())
+		cl, err = UserClient(context.Background(), adminUser)
 		if err != nil {
 			log.Fatal().Err(err).Msg("failed to build DAML client")
 		}
@@ -255,6 +251,10 @@ func initDamlSandbox(ctx context.Context, dockerPool *dockertest.Pool) (*dockert
         address = "0.0.0.0"
         port = 6865
         user-management-service.enabled = true
+        auth-services = [{
+          type = unsafe-jwt-hmac-256
+          secret = "` + jwtSecret + `"
+        }]
       }
     }
   }
@@ -410,6 +410,28 @@ func GetClient() *client.DamlBindingClient {
 	return cl
 }
 
+// UserClient connects to the sandbox as the given Ledger API user, with a token
+// signed by the sandbox's HMAC secret.
+func UserClient(ctx context.Context, userID string) (*client.DamlBindingClient, error) {
+	signer, err := auth.NewJWTSigner([]byte(jwtSecret), "")
+	if err != nil {
+		return nil, err
+	}
+	provider := auth.NewSelfIssuedTokenProvider(signer, auth.LedgerClaims{
+		UserID: userID,
+		Scope:  auth.LedgerAPIScope,
+	})
+
+	builder := client.NewDamlClient(grpcAddr, provider).
+		WithAdminAddress(adminAddr).
+		WithAdminTokenProvider(auth.NewNoAuth())
+	if strings.HasSuffix(grpcAddr, ":443") {
+		builder = builder.WithTLSConfig(client.TlsConfig{})
+	}
+
+	return builder.Build(ctx)
+}
+
 func GetAdminAddr() string {
 	return adminAddr
 }
@@ -441,6 +463,10 @@ func waitForSynchronizerConnection(ctx context.Context, cl *client.DamlBindingCl
 			log.Debug().Err(err).Msg("checking for synchronizer connection")
 		}
 
+		if err := cl.SynchronizerConnectivity.ReconnectSynchronizers(ctx, true); err != nil {
+			log.Debug().Err(err).Msg("reconnecting registered synchronizers")
+		}
+
 		time.Sleep(2 * time.Second)
 	}
 